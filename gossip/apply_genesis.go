@@ -145,7 +145,7 @@ func (s *Store) applyEpoch1Genesis(blockProc BlockProc, g opera.Genesis) (err er
 	txListener := blockProc.TxListenerModule.Start(blockCtx, bs, es, statedb)
 	evmProcessor := blockProc.EVMModule.Start(blockCtx, statedb, evmStateReader, func(l *types.Log) {
 		txListener.OnNewLog(l)
-		sfcapi.OnNewLog(s.sfcapi, l)
+		sfcapi.OnNewLog(s.sfcapi, l, es.Epoch, blockCtx.Time)
 	}, es.Rules)
 
 	// Execute genesis-internal transactions
@@ -160,8 +160,10 @@ func (s *Store) applyEpoch1Genesis(blockProc BlockProc, g opera.Genesis) (err er
 
 	// Seal epoch if requested
 	if sealing {
+		s.evm.SetEpochSealBlock(es.Epoch, blockCtx.Idx)
 		sealer.Update(bs, es)
 		bs, es = sealer.SealEpoch()
+		s.SetValidatorKeys(es.ValidatorProfiles)
 		txListener.Update(bs, es)
 	}
 
@@ -227,7 +229,8 @@ func (s *Store) applyEpoch1Genesis(blockProc BlockProc, g opera.Genesis) (err er
 	s.SetGenesisBlockIndex(blockCtx.Idx)
 
 	// index data for legacy SFC API
-	sfcapi.ApplyGenesis(s.sfcapi, s.evm.EvmLogs())
+	sfcapi.ApplyGenesis(s.sfcapi, s.evm.EvmLogs(), es.Epoch)
+	sfcapi.OnNewEpoch(s.sfcapi, es.Epoch, es.Validators.SortedIDs())
 
 	return nil
 }
@@ -0,0 +1,57 @@
+package gossip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrBlockNotFound is returned if requested block is unknown
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// TxWithMeta is a transaction of a block along with its execution result
+type TxWithMeta struct {
+	Tx *types.Transaction
+	// Index is the position of the transaction in the block
+	Index   uint
+	GasUsed uint64
+	Status  uint64
+}
+
+// GetBlockTransactions returns the not skipped transactions of the block in order, along with their execution results.
+// The block and its receipts are read through the caches.
+// Returns ErrBlockNotFound if the block is unknown, and an error if the block receipts aren't indexed.
+func (r *EvmStateReader) GetBlockTransactions(n uint64) ([]TxWithMeta, error) {
+	block := r.GetBlock(common.Hash{}, n)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, n)
+	}
+	if len(block.Transactions) == 0 {
+		return []TxWithMeta{}, nil
+	}
+	receipts := r.store.evm.GetReceipts(idx.Block(n))
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts of block %d aren't indexed, receipts_num=%d, txs_num=%d", n, len(receipts), len(block.Transactions))
+	}
+	// gas used by a transaction is derived from the cumulative gas used
+	derived, err := r.deriveReceipts(block, receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]TxWithMeta, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		txs[i] = TxWithMeta{
+			Tx:      tx,
+			Index:   uint(i),
+			GasUsed: derived[i].GasUsed,
+			Status:  derived[i].Status,
+		}
+	}
+	return txs, nil
+}
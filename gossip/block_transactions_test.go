@@ -0,0 +1,61 @@
+package gossip
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestGetBlockTransactions(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	n := env.store.GetLatestBlockIndex() + 1
+	txs := types.Transactions{
+		types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(0), nil),
+		types.NewTransaction(1, common.Address{2}, big.NewInt(1), 30000, big.NewInt(0), nil),
+		types.NewTransaction(2, common.Address{3}, big.NewInt(1), 50000, big.NewInt(0), nil),
+	}
+	for _, tx := range txs {
+		env.store.evm.SetTx(tx.Hash(), tx)
+	}
+	env.store.SetBlock(n, &inter.Block{
+		Atropos:     hash.FakeEvent(),
+		InternalTxs: []common.Hash{txs[0].Hash()},
+		Txs:         []common.Hash{txs[1].Hash(), txs[2].Hash()},
+	})
+	env.store.evm.SetReceipts(n, types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000},
+		{Status: types.ReceiptStatusFailed, CumulativeGasUsed: 51000},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 91000},
+	})
+
+	got, err := env.stateReader.GetBlockTransactions(uint64(n))
+	require.NoError(err)
+	require.Len(got, len(txs))
+	for i, gasUsed := range []uint64{21000, 30000, 40000} {
+		require.Equal(txs[i].Hash(), got[i].Tx.Hash())
+		require.Equal(uint(i), got[i].Index)
+		require.Equal(gasUsed, got[i].GasUsed)
+	}
+	require.Equal(types.ReceiptStatusSuccessful, got[0].Status)
+	require.Equal(types.ReceiptStatusFailed, got[1].Status)
+	require.Equal(types.ReceiptStatusSuccessful, got[2].Status)
+
+	// cached receipts aren't modified
+	require.Zero(env.store.evm.GetReceipts(n)[1].GasUsed)
+
+	_, err = env.stateReader.GetBlockTransactions(uint64(n) + 1)
+	require.True(errors.Is(err, ErrBlockNotFound))
+}
@@ -116,6 +116,32 @@ func maxBlockIdx(a, b idx.Block) idx.Block {
 	return b
 }
 
+// SealEpochMetrics calculates validators metrics which are passed into Driver on epoch sealing.
+// Metrics are ordered by validators indexes in the sealed epoch.
+func SealEpochMetrics(block blockproc.BlockCtx, bs blockproc.BlockState, es blockproc.EpochState) []drivercall.ValidatorEpochMetric {
+	metrics := make([]drivercall.ValidatorEpochMetric, es.Validators.Len())
+	for oldValIdx := idx.Validator(0); oldValIdx < es.Validators.Len(); oldValIdx++ {
+		info := bs.ValidatorStates[oldValIdx]
+		// forgive downtime if below BlockMissedSlack
+		missed := opera.BlocksMissed{
+			BlocksNum: maxBlockIdx(block.Idx, info.LastBlock) - info.LastBlock,
+			Period:    inter.MaxTimestamp(block.Time, info.LastOnlineTime) - info.LastOnlineTime,
+		}
+		uptime := info.Uptime
+		if missed.BlocksNum <= es.Rules.Economy.BlockMissedSlack {
+			missed = opera.BlocksMissed{}
+			prevOnlineTime := inter.MaxTimestamp(info.LastOnlineTime, es.EpochStart)
+			uptime += inter.MaxTimestamp(block.Time, prevOnlineTime) - prevOnlineTime
+		}
+		metrics[oldValIdx] = drivercall.ValidatorEpochMetric{
+			Missed:          missed,
+			Uptime:          uptime,
+			OriginatedTxFee: info.Originated,
+		}
+	}
+	return metrics
+}
+
 func (p *DriverTxPreTransactor) PopInternalTxs(block blockproc.BlockCtx, bs blockproc.BlockState, es blockproc.EpochState, sealing bool, statedb *state.StateDB) types.Transactions {
 	buildTx := internalTxBuilder(statedb)
 	internalTxs := make(types.Transactions, 0, 8)
@@ -133,26 +159,7 @@ func (p *DriverTxPreTransactor) PopInternalTxs(block blockproc.BlockCtx, bs bloc
 
 	// push data into Driver before epoch sealing
 	if sealing {
-		metrics := make([]drivercall.ValidatorEpochMetric, es.Validators.Len())
-		for oldValIdx := idx.Validator(0); oldValIdx < es.Validators.Len(); oldValIdx++ {
-			info := bs.ValidatorStates[oldValIdx]
-			// forgive downtime if below BlockMissedSlack
-			missed := opera.BlocksMissed{
-				BlocksNum: maxBlockIdx(block.Idx, info.LastBlock) - info.LastBlock,
-				Period:    inter.MaxTimestamp(block.Time, info.LastOnlineTime) - info.LastOnlineTime,
-			}
-			uptime := info.Uptime
-			if missed.BlocksNum <= es.Rules.Economy.BlockMissedSlack {
-				missed = opera.BlocksMissed{}
-				prevOnlineTime := inter.MaxTimestamp(info.LastOnlineTime, es.EpochStart)
-				uptime += inter.MaxTimestamp(block.Time, prevOnlineTime) - prevOnlineTime
-			}
-			metrics[oldValIdx] = drivercall.ValidatorEpochMetric{
-				Missed:          missed,
-				Uptime:          uptime,
-				OriginatedTxFee: info.Originated,
-			}
-		}
+		metrics := SealEpochMetrics(block, bs, es)
 		calldata := drivercall.SealEpoch(metrics)
 		internalTxs = append(internalTxs, buildTx(calldata, driver.ContractAddress))
 	}
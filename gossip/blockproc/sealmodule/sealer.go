@@ -41,15 +41,20 @@ func (p *OperaEpochsSealer) Update(bs blockproc.BlockState, es blockproc.EpochSt
 	p.bs, p.es = bs, es
 }
 
+// NextValidators builds validators of the next epoch from the block state
+func NextValidators(bs blockproc.BlockState) *pos.Validators {
+	builder := pos.NewBigBuilder()
+	for v, profile := range bs.NextValidatorProfiles {
+		builder.Set(v, profile.Weight)
+	}
+	return builder.Build()
+}
+
 // SealEpoch is called after pre-internal transactions are executed
 func (s *OperaEpochsSealer) SealEpoch() (blockproc.BlockState, blockproc.EpochState) {
 	// Select new validators
 	oldValidators := s.es.Validators
-	builder := pos.NewBigBuilder()
-	for v, profile := range s.bs.NextValidatorProfiles {
-		builder.Set(v, profile.Weight)
-	}
-	newValidators := builder.Build()
+	newValidators := NextValidators(s.bs)
 	s.es.Validators = newValidators
 	s.es.ValidatorProfiles = s.bs.NextValidatorProfiles.Copy()
 
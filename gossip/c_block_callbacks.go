@@ -148,7 +148,7 @@ func consensusCallbackBeginBlockFn(
 					if verWatcher != nil {
 						verWatcher.OnNewLog(l)
 					}
-					sfcapi.OnNewLog(store.sfcapi, l)
+					onNewSfcLog(store, feed, l, es.Epoch, blockCtx.Time)
 				}
 				evmProcessor := blockProc.EVMModule.Start(blockCtx, statedb, evmStateReader, onNewLogAll, es.Rules)
 
@@ -164,9 +164,12 @@ func consensusCallbackBeginBlockFn(
 
 				// Seal epoch if requested
 				if sealing {
+					store.evm.SetEpochSealBlock(es.Epoch, blockCtx.Idx)
 					sealer.Update(bs, es)
 					bs, es = sealer.SealEpoch() // TODO: refactor to not mutate the bs, it is unclear
 					store.SetBlockEpochState(bs, es)
+					store.SetValidatorKeys(es.ValidatorProfiles)
+					sfcapi.OnNewEpoch(store.sfcapi, es.Epoch, es.Validators.SortedIDs())
 					newValidators = es.Validators
 					txListener.Update(bs, es)
 				}
@@ -267,6 +270,10 @@ func consensusCallbackBeginBlockFn(
 
 					store.SetBlock(blockCtx.Idx, block)
 					store.SetBlockIndex(block.Atropos, blockCtx.Idx)
+					// the Atropos is stored before the block is processed, it's missing only if the DB is corrupted
+					if atropos := store.GetEvent(cBlock.Atropos); atropos != nil {
+						store.evm.SetBlockValidator(blockCtx.Idx, atropos.Creator())
+					}
 					bs.LastBlock = blockCtx
 					store.SetBlockEpochState(bs, es)
 					store.EvmStore().SetCachedEvmBlock(blockCtx.Idx, evmBlock)
@@ -314,6 +321,14 @@ func consensusCallbackBeginBlockFn(
 	}
 }
 
+// onNewSfcLog indexes the SFC log and notifies the staker events subscribers.
+// The feed is nil if there's no Service, e.g. in tests.
+func onNewSfcLog(store *Store, feed *ServiceFeed, l *types.Log, epoch idx.Epoch, blockTime inter.Timestamp) {
+	if e := sfcapi.OnNewLog(store.sfcapi, l, epoch, blockTime); e != nil && feed != nil {
+		feed.stakerEvents.Send(*e)
+	}
+}
+
 // spillBlockEvents excludes first events which exceed MaxBlockGas
 func spillBlockEvents(store *Store, block *inter.Block, network opera.Rules) (*inter.Block, inter.EventPayloads) {
 	fullEvents := make(inter.EventPayloads, len(block.Events))
@@ -5,10 +5,16 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/lachesis"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Fantom-foundation/go-opera/gossip/sfcapi"
+	"github.com/Fantom-foundation/go-opera/inter"
 	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/opera/genesis/sfc"
 	"github.com/Fantom-foundation/go-opera/utils"
 )
 
@@ -62,3 +68,151 @@ func TestConsensusCallback(t *testing.T) {
 	}
 
 }
+
+func TestSealEpochSfcBalance(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	sfcBalanceAtHead := func() *big.Int {
+		block := env.store.GetBlock(env.store.GetLatestBlockIndex())
+		balance, err := env.store.evm.GetContractBalance(block.Root, sfc.ContractAddress)
+		require.NoError(err)
+		require.Equal(env.State().GetBalance(sfc.ContractAddress), balance)
+		return balance
+	}
+
+	env.ApplyBlock(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	before := sfcBalanceAtHead()
+	require.Equal(1, before.Sign())
+	epoch := env.store.GetEpoch()
+
+	env.ApplyBlock(nextEpoch)
+	require.Equal(epoch+1, env.store.GetEpoch())
+	// rewards aren't transferred into SFC at the sealing, they're minted on claims
+	require.Equal(before, sfcBalanceAtHead())
+}
+
+func TestBlockValidator(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	genesis := env.store.GetLatestBlockIndex()
+	for i := 0; i < 5; i++ {
+		tm := sameEpoch
+		if i == 2 {
+			tm = nextEpoch
+		}
+		env.ApplyBlock(tm)
+	}
+
+	// genesis blocks have no Atropos events
+	for n := idx.Block(0); n <= genesis; n++ {
+		_, ok := env.store.evm.GetBlockValidator(uint64(n))
+		require.False(ok, n)
+	}
+	latest := env.store.GetLatestBlockIndex()
+	require.Greater(latest, genesis)
+	for n := genesis + 1; n <= latest; n++ {
+		block := env.store.GetBlock(n)
+		got, ok := env.store.evm.GetBlockValidator(uint64(n))
+		require.True(ok, n)
+		require.Equal(env.store.GetEvent(block.Atropos).Creator(), got, n)
+	}
+
+	// the degenerate block isn't applied, so its validator isn't indexed
+	eBuilder := inter.MutableEventPayload{}
+	eBuilder.SetCreator(1)
+	eBuilder.SetMedianTime(inter.Timestamp(env.lastBlockTime.Add(sameEpoch).UnixNano()))
+	atropos := eBuilder.Build()
+	env.store.SetEvent(atropos)
+	process := env.consensusCallbackBeginBlockFn(nil)(&lachesis.Block{
+		Atropos: atropos.ID(),
+	})
+	process.EndBlock()
+	env.blockProcWg.Wait()
+	require.Equal(latest, env.store.GetLatestBlockIndex())
+	_, ok := env.store.evm.GetBlockValidator(uint64(latest + 1))
+	require.False(ok)
+}
+
+func TestEpochValidatorsIndex(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	genesis := env.store.GetEpoch()
+	env.ApplyBlock(nextEpoch)
+	env.ApplyBlock(nextEpoch)
+	latest := env.store.GetEpoch()
+	require.Equal(genesis+2, latest)
+
+	min, max, ok := env.store.sfcapi.AvailableValidatorEpochs()
+	require.True(ok)
+	require.Equal(genesis, min)
+	require.Equal(latest, max)
+
+	// validators of the fake genesis don't change
+	validators := env.store.GetValidators()
+	for epoch := genesis; epoch <= latest; epoch++ {
+		require.Len(env.store.sfcapi.GetEpochValidators(epoch), int(validators.Len()), epoch)
+		for _, id := range validators.IDs() {
+			membership := env.store.sfcapi.GetValidatorMembership(epoch, id)
+			require.True(membership.CurrentEpochActive, epoch)
+			require.Equal(epoch != latest, membership.NextEpochActive, epoch)
+			require.Equal(epoch == latest, membership.Provisional, epoch)
+		}
+		require.False(env.store.sfcapi.GetValidatorMembership(epoch, idx.ValidatorID(validators.Len()+1)).CurrentEpochActive)
+	}
+
+	joined, left, err := env.store.sfcapi.ValidatorSetDiff(genesis, latest)
+	require.NoError(err)
+	require.Empty(joined)
+	require.Empty(left)
+}
+
+func TestOnNewSfcLog(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	delegator := common.Address{1}
+	delegated := func(amount int64) *types.Log {
+		return &types.Log{
+			Address: sfc.ContractAddress,
+			Topics: []common.Hash{
+				sfcapi.Topics.Delegated,
+				delegator.Hash(),
+				common.BigToHash(big.NewInt(2)),
+			},
+			Data: common.BigToHash(big.NewInt(amount)).Bytes(),
+		}
+	}
+	id := sfcapi.DelegationID{Delegator: delegator, StakerID: 2}
+
+	// the log is indexed without a feed
+	onNewSfcLog(env.store, nil, delegated(10), 2, 100)
+	require.Equal(big.NewInt(10), env.store.sfcapi.GetSfcDelegation(id).Amount)
+
+	feed := &ServiceFeed{}
+	events := make(chan sfcapi.StakerEvent, 1)
+	sub := feed.SubscribeStakerEvents(events)
+	defer sub.Unsubscribe()
+	onNewSfcLog(env.store, feed, delegated(5), 2, 100)
+	require.Equal(big.NewInt(15), env.store.sfcapi.GetSfcDelegation(id).Amount)
+	require.Equal(sfcapi.StakerEvent{
+		Type:      sfcapi.StakerStakeIncreased,
+		StakerID:  2,
+		Epoch:     2,
+		BlockTime: 100,
+	}, <-events)
+}
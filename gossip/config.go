@@ -21,6 +21,7 @@ import (
 	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
 	"github.com/Fantom-foundation/go-opera/gossip/filters"
 	"github.com/Fantom-foundation/go-opera/gossip/gasprice"
+	"github.com/Fantom-foundation/go-opera/gossip/sfcapi"
 )
 
 const nominalSize uint = 1
@@ -85,6 +86,9 @@ type (
 		ExtRPCEnabled bool
 
 		RPCLogsBloom bool
+
+		// MaxMissedBlocksRatio is a share of the epoch blocks, missing more of which is reported by CheckValidatorUptime
+		MaxMissedBlocksRatio float64
 	}
 
 	StoreCacheConfig struct {
@@ -94,6 +98,8 @@ type (
 		// Cache size for full blocks.
 		BlocksNum  int
 		BlocksSize uint
+		// Cache size for senders of transactions (number of transactions).
+		TxSendersNum int
 	}
 
 	// StoreConfig is a config for store db.
@@ -103,6 +109,23 @@ type (
 		EVM                 evmstore.StoreConfig
 		MaxNonFlushedSize   int
 		MaxNonFlushedPeriod time.Duration
+		// MaxNonFlushedBlocks is a number of processed blocks which triggers DB flush, bounding the re-processing after a crash (0 means no limit)
+		MaxNonFlushedBlocks idx.Block
+		// Compaction is a config of the background main DB compaction
+		Compaction CompactionConfig
+		// SfcAPI is a config of the SFC index
+		SfcAPI sfcapi.Config
+	}
+
+	// CompactionConfig is a config of the background main DB compaction.
+	CompactionConfig struct {
+		// Period of checking whether compaction is needed. Zero disables the background compaction, which is the default
+		Period time.Duration
+		// DeletedKeysSizeThreshold is a total size of deleted keys (values aren't counted) which triggers compaction
+		DeletedKeysSizeThreshold uint64
+		// Start and Limit bound the compacted key range. Nil means the range is unbounded
+		Start []byte
+		Limit []byte
 	}
 )
 
@@ -183,6 +206,8 @@ func DefaultConfig(scale cachescale.Func) Config {
 		},
 		RPCLogsBloom: true,
 
+		MaxMissedBlocksRatio: 0.5,
+
 		RPCGasCap:   25000000,
 		RPCTxFeeCap: 100, // 100 FTM
 	}
@@ -224,6 +249,17 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// Validate checks the EVM store config, and that the EVM store tables don't overlap with the main DB tables.
+func (c *StoreConfig) Validate() error {
+	if err := c.EVM.Validate(); err != nil {
+		return err
+	}
+	if err := c.EVM.Tables.Validate(mainDBTables()...); err != nil {
+		return fmt.Errorf("invalid EVM store tables: %w", err)
+	}
+	return nil
+}
+
 // FakeConfig returns the default configurations for the gossip service in fakenet.
 func FakeConfig(num int, scale cachescale.Func) Config {
 	cfg := DefaultConfig(scale)
@@ -235,14 +271,21 @@ func FakeConfig(num int, scale cachescale.Func) Config {
 func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 	return StoreConfig{
 		Cache: StoreCacheConfig{
-			EventsNum:  scale.I(5000),
-			EventsSize: scale.U(6 * opt.MiB),
-			BlocksNum:  scale.I(5000),
-			BlocksSize: scale.U(512 * opt.KiB),
+			EventsNum:    scale.I(5000),
+			EventsSize:   scale.U(6 * opt.MiB),
+			BlocksNum:    scale.I(5000),
+			BlocksSize:   scale.U(512 * opt.KiB),
+			TxSendersNum: scale.I(20000),
 		},
 		EVM:                 evmstore.DefaultStoreConfig(scale),
 		MaxNonFlushedSize:   17*opt.MiB + scale.I(5*opt.MiB),
 		MaxNonFlushedPeriod: 30 * time.Minute,
+		Compaction: CompactionConfig{
+			// the background compaction is disabled by default, set Period to enable it
+			Period:                   0,
+			DeletedKeysSizeThreshold: 64 * opt.MiB,
+		},
+		SfcAPI: sfcapi.DefaultConfig(),
 	}
 }
 
@@ -250,14 +293,16 @@ func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 func LiteStoreConfig() StoreConfig {
 	return StoreConfig{
 		Cache: StoreCacheConfig{
-			EventsNum:  500,
-			EventsSize: 512 * opt.KiB,
-			BlocksNum:  100,
-			BlocksSize: 50 * opt.KiB,
+			EventsNum:    500,
+			EventsSize:   512 * opt.KiB,
+			BlocksNum:    100,
+			BlocksSize:   50 * opt.KiB,
+			TxSendersNum: 500,
 		},
 		EVM:                 evmstore.LiteStoreConfig(),
 		MaxNonFlushedSize:   800 * opt.KiB,
 		MaxNonFlushedPeriod: 30 * time.Minute,
+		SfcAPI:              sfcapi.DefaultConfig(),
 	}
 }
 
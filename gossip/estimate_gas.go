@@ -0,0 +1,63 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/opera"
+)
+
+// EstimateGas returns the minimal gas limit, with which the message is executed successfully on top of the state root.
+// Every execution is done on a throwaway state, so state changes are discarded.
+// The throwaway states count towards the limit of concurrent state databases, see evmstore.Store.StateDBCtx.
+// Zero gasCap means the max gas limit of the network rules.
+// If the message fails even with gasCap, the execution error is returned, including the revert reason if any.
+func (r *EvmStateReader) EstimateGas(root hash.Hash, msg evmcore.Message, gasCap uint64) (uint64, error) {
+	if gasCap == 0 {
+		gasCap = r.MaxGasLimit()
+	}
+	header := r.CurrentHeader()
+	execute := func(gas uint64) (*evmcore.ExecutionResult, error) {
+		statedb, release, err := r.store.evm.StateDBCtx(context.Background(), root)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		msg := types.NewMessage(msg.From(), msg.To(), msg.Nonce(), msg.Value(), gas, msg.GasPrice(), msg.Data(), msg.AccessList(), false)
+		evm := vm.NewEVM(evmcore.NewEVMBlockContext(header, r, nil), evmcore.NewEVMTxContext(msg), statedb, r.Config(), opera.DefaultVMConfig)
+		return evmcore.ApplyMessage(evm, msg, new(evmcore.GasPool).AddGas(gas))
+	}
+
+	res, err := execute(gasCap)
+	if err != nil {
+		return 0, err
+	}
+	if res.Failed() {
+		if len(res.Revert()) > 0 {
+			if reason, errUnpack := abi.UnpackRevert(res.Revert()); errUnpack == nil {
+				return 0, fmt.Errorf("%w: %v", res.Err, reason)
+			}
+		}
+		return 0, res.Err
+	}
+
+	// binary search of the minimal executable gas limit
+	lo, hi := params.TxGas-1, gasCap
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		res, err := execute(mid)
+		if err != nil || res.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
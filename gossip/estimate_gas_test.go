@@ -0,0 +1,57 @@
+package gossip
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestEstimateGas(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	var (
+		from     = common.Address{1}
+		to       = common.Address{2}
+		reverter = common.Address{3}
+		// copies the revert data from the code and reverts with Error("no")
+		revertData = append(common.FromHex("0x08c379a0"), append(common.LeftPadBytes([]byte{0x20}, 32),
+			append(common.LeftPadBytes([]byte{2}, 32), common.RightPadBytes([]byte("no"), 32)...)...)...)
+		revertCode = append(common.FromHex("0x6064600c60003960646000fd"), revertData...)
+	)
+	statedb, err := env.store.evm.StateDB(env.store.GetBlockState().FinalizedStateRoot)
+	require.NoError(err)
+	statedb.AddBalance(from, big.NewInt(1e18))
+	statedb.SetCode(reverter, revertCode)
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+
+	// simple transfer
+	msg := types.NewMessage(from, &to, 0, big.NewInt(1), 0, big.NewInt(0), nil, nil, false)
+	gas, err := env.stateReader.EstimateGas(hash.Hash(root), msg, 0)
+	require.NoError(err)
+	require.Equal(params.TxGas, gas)
+
+	// state changes are discarded
+	statedb, err = env.store.evm.StateDB(hash.Hash(root))
+	require.NoError(err)
+	require.Zero(statedb.GetBalance(to).Sign())
+
+	// reverting call
+	msg = types.NewMessage(from, &reverter, 0, big.NewInt(0), 0, big.NewInt(0), nil, nil, false)
+	_, err = env.stateReader.EstimateGas(hash.Hash(root), msg, 0)
+	require.True(errors.Is(err, vm.ErrExecutionReverted), err)
+	require.Contains(err.Error(), "no")
+}
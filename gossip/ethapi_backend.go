@@ -2,7 +2,6 @@ package gossip
 
 import (
 	"context"
-	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
@@ -25,11 +24,10 @@ import (
 	"github.com/Fantom-foundation/go-opera/ethapi"
 	"github.com/Fantom-foundation/go-opera/evmcore"
 	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
 	"github.com/Fantom-foundation/go-opera/gossip/sfcapi"
 	"github.com/Fantom-foundation/go-opera/inter"
-	"github.com/Fantom-foundation/go-opera/inter/drivertype"
 	"github.com/Fantom-foundation/go-opera/opera"
-	"github.com/Fantom-foundation/go-opera/topicsdb"
 	"github.com/Fantom-foundation/go-opera/tracing"
 )
 
@@ -87,7 +85,9 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 	return blk, nil
 }
 
-func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *evmcore.EvmHeader, error) {
+// StateAndHeaderByNumberOrHash returns the state and the header of the block.
+// The state counts towards the limit of concurrent state databases, see evmstore.Store.StateDBCtx.
+func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *evmcore.EvmHeader, func(), error) {
 	var header *evmcore.EvmHeader
 	if number, ok := blockNrOrHash.Number(); ok && (number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber) {
 		header = &b.state.CurrentBlock().EvmHeader
@@ -96,20 +96,20 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 	} else if h, ok := blockNrOrHash.Hash(); ok {
 		index := b.svc.store.GetBlockIndex(hash.Event(h))
 		if index == nil {
-			return nil, nil, errors.New("header not found")
+			return nil, nil, nil, errors.New("header not found")
 		}
 		header = b.state.GetHeader(common.Hash{}, uint64(*index))
 	} else {
-		return nil, nil, errors.New("unknown header selector")
+		return nil, nil, nil, errors.New("unknown header selector")
 	}
 	if header == nil {
-		return nil, nil, errors.New("header not found")
+		return nil, nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.svc.store.evm.StateDB(hash.Hash(header.Root))
+	stateDb, release, err := b.svc.store.evm.StateDBCtx(ctx, hash.Hash(header.Root))
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return stateDb, header, nil
+	return stateDb, header, release, nil
 }
 
 // decodeShortEventID decodes ShortID
@@ -357,20 +357,9 @@ func (b *EthAPIBackend) GetTransaction(ctx context.Context, txHash common.Hash)
 		return nil, 0, 0, nil
 	}
 
-	var tx *types.Transaction
-	if position.Event.IsZero() {
-		tx = b.svc.store.evm.GetTx(txHash)
-	} else {
-		event := b.svc.store.GetEventPayload(position.Event)
-		if position.EventOffset > uint32(event.Txs().Len()) {
-			return nil, 0, 0, fmt.Errorf("transactions index is corrupted (offset is larger than number of txs in event), event=%s, txid=%s, block=%d, offset=%d, txs_num=%d",
-				position.Event.String(),
-				txHash.String(),
-				position.Block,
-				position.EventOffset,
-				event.Txs().Len())
-		}
-		tx = event.Txs()[position.EventOffset]
+	tx, err := b.svc.store.getPositionedTx(txHash, position)
+	if err != nil {
+		return nil, 0, 0, err
 	}
 
 	return tx, uint64(position.Block), uint64(position.BlockOffset), nil
@@ -418,6 +407,10 @@ func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.svc.store.evm.EvmTable()
 }
 
+func (b *EthAPIBackend) TrieDBStats() evmstore.TrieDBStats {
+	return b.svc.store.evm.TrieDBStats()
+}
+
 func (b *EthAPIBackend) AccountManager() *accounts.Manager {
 	return b.svc.AccountManager()
 }
@@ -438,8 +431,9 @@ func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.svc.config.RPCTxFeeCap
 }
 
-func (b *EthAPIBackend) EvmLogIndex() *topicsdb.Index {
-	return b.svc.store.evm.EvmLogs()
+// FindLogsInBlocks returns log records of block range by pattern, the result size is limited by the EVM store config.
+func (b *EthAPIBackend) FindLogsInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash) ([]*types.Log, error) {
+	return b.svc.store.evm.FindLogsInBlocks(ctx, from, to, pattern)
 }
 
 // CurrentEpoch returns current epoch number.
@@ -545,9 +539,6 @@ func (b *EthAPIBackend) extendStaker(stakerID idx.ValidatorID, staker *sfcapi.Sf
 	if staker.Status == 1 {
 		staker.Status = 0
 	}
-	if staker.Status == drivertype.DoublesignBit {
-		staker.Status = sfcapi.ForkBit
-	}
 	if staker.Status == 1<<3 {
 		staker.Status = sfcapi.OfflineBit
 	}
@@ -565,16 +556,8 @@ func (b *EthAPIBackend) GetStaker(ctx context.Context, stakerID idx.ValidatorID)
 }
 
 func (b *EthAPIBackend) GetStakerID(ctx context.Context, addr common.Address) (idx.ValidatorID, error) {
-	var found *idx.ValidatorID
-	b.svc.store.sfcapi.ForEachSfcStaker(func(id sfcapi.SfcStakerAndID) {
-		if id.Staker.Address == addr {
-			found = &id.StakerID
-		}
-	})
-	if found == nil {
-		return 0, nil
-	}
-	return *found, nil
+	stakerID, _ := b.svc.store.sfcapi.GetStakerIDByAddress(addr)
+	return stakerID, nil
 }
 
 func (b *EthAPIBackend) GetStakers(ctx context.Context) ([]sfcapi.SfcStakerAndID, error) {
@@ -1,6 +1,8 @@
 package gossip
 
 import (
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
@@ -82,6 +84,13 @@ func (r *EvmStateReader) CurrentHeader() *evmcore.EvmHeader {
 	return r.getBlock(hash.Event{}, n, false).Header()
 }
 
+// CurrentHead returns the current block number, state root and hash.
+// They're read from the same block state, so they always belong to the same block.
+func (r *EvmStateReader) CurrentHead() (number uint64, root hash.Hash, h common.Hash) {
+	bs := r.store.GetBlockState()
+	return uint64(bs.LastBlock.Idx), bs.FinalizedStateRoot, common.Hash(bs.LastBlock.Atropos)
+}
+
 func (r *EvmStateReader) GetHeader(h common.Hash, n uint64) *evmcore.EvmHeader {
 	return r.getBlock(hash.Event(h), idx.Block(n), false).Header()
 }
@@ -139,7 +148,12 @@ func (r *EvmStateReader) getBlock(h hash.Event, n idx.Block, readTxs bool) *evmc
 
 	var prev hash.Event
 	if n != 0 {
-		prev = r.store.GetBlock(n - 1).Atropos
+		prevBlock := r.store.GetBlock(n - 1)
+		if prevBlock == nil {
+			// the parent hash is unknown if the canonical chain has a gap
+			return nil
+		}
+		prev = prevBlock.Atropos
 	}
 	evmHeader := evmcore.ToEvmHeader(block, n, prev)
 
@@ -157,6 +171,58 @@ func (r *EvmStateReader) getBlock(h hash.Event, n idx.Block, readTxs bool) *evmc
 	return evmBlock
 }
 
+// StreamHeaders calls fn with headers of the blocks from..to in order. Block transactions aren't read.
+// It stops on the first missing block, or when fn returns an error, which is returned.
+func (r *EvmStateReader) StreamHeaders(from, to uint64, fn func(*evmcore.EvmHeader) error) error {
+	var prev hash.Event
+	if from != 0 {
+		prevBlock := r.store.GetBlock(idx.Block(from - 1))
+		if prevBlock == nil {
+			return nil
+		}
+		prev = prevBlock.Atropos
+	}
+	for n := from; n <= to; n++ {
+		block := r.store.GetBlock(idx.Block(n))
+		if block == nil {
+			return nil
+		}
+		if err := fn(evmcore.ToEvmHeader(block, idx.Block(n), prev)); err != nil {
+			return err
+		}
+		prev = block.Atropos
+		if n == math.MaxUint64 {
+			break
+		}
+	}
+	return nil
+}
+
+// VerifyCanonicalChain checks the continuity of the blocks from..to: every block is present,
+// and it's indexed by its hash in the independently stored blocks index. Parent hashes aren't checked,
+// as headers derive them from the previous blocks. Block transactions aren't read.
+// Returns the first block which breaks the continuity and false, or true if the blocks are continuous.
+func (r *EvmStateReader) VerifyCanonicalChain(from, to uint64) (firstGap uint64, ok bool, err error) {
+	if from > to {
+		return 0, false, fmt.Errorf("invalid blocks range %d..%d", from, to)
+	}
+	for n := from; n <= to; n++ {
+		h := r.GetHeader(common.Hash{}, n)
+		if h == nil {
+			return n, false, nil
+		}
+		if indexed := r.store.GetBlockIndex(hash.Event(h.Hash)); indexed == nil || uint64(*indexed) != n {
+			return n, false, nil
+		}
+		if n == math.MaxUint64 {
+			break
+		}
+	}
+	return 0, true, nil
+}
+
+// StateAt returns the state on top of the root, it's used by the tx pool and the internal verifications.
+// The state isn't limited by StoreConfig.MaxStateDBs, so the tx pool isn't stalled by RPC calls.
 func (r *EvmStateReader) StateAt(root common.Hash) (*state.StateDB, error) {
 	return r.store.evm.StateDB(hash.Hash(root))
 }
@@ -0,0 +1,174 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/integration/makegenesis"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestEvmStateReaderCurrentHead(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	bs, es := env.store.GetBlockEpochState()
+	first := bs.LastBlock.Idx + 1
+	const blocks = 1000
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := first; n < first+blocks; n++ {
+			bs.LastBlock.Idx = n
+			bs.LastBlock.Atropos = hash.Event(hash.FakeHash(int64(n)))
+			bs.FinalizedStateRoot = hash.Hash(hash.FakeHash(-int64(n)))
+			env.store.SetBlockEpochState(bs, es)
+		}
+	}()
+
+	for {
+		number, root, h := env.stateReader.CurrentHead()
+		if number >= uint64(first) {
+			require.Equal(hash.Hash(hash.FakeHash(-int64(number))), root)
+			require.Equal(common.Hash(hash.FakeHash(int64(number))), h)
+		}
+		if number == uint64(first+blocks-1) {
+			break
+		}
+	}
+	wg.Wait()
+	number, _, _ := env.stateReader.CurrentHead()
+	require.Equal(first+blocks-1, idx.Block(number))
+}
+
+func TestEvmStateReaderStreamHeaders(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	const blocks = 5
+	for i := 0; i < blocks; i++ {
+		env.ApplyBlock(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	}
+	last := uint64(env.store.GetLatestBlockIndex())
+	from := last - blocks + 1
+
+	var got []uint64
+	err := env.stateReader.StreamHeaders(from, last+10, func(h *evmcore.EvmHeader) error {
+		got = append(got, h.Number.Uint64())
+		expected := env.stateReader.GetHeader(common.Hash{}, h.Number.Uint64())
+		require.Equal(expected.Hash, h.Hash)
+		require.Equal(expected.ParentHash, h.ParentHash)
+		return nil
+	})
+	require.NoError(err)
+	require.Len(got, blocks)
+	for i, n := range got {
+		require.Equal(from+uint64(i), n)
+	}
+
+	// the callback error stops the streaming
+	stop := errors.New("stop")
+	calls := 0
+	err = env.stateReader.StreamHeaders(from, last, func(h *evmcore.EvmHeader) error {
+		calls++
+		return stop
+	})
+	require.Equal(stop, err)
+	require.Equal(1, calls)
+}
+
+func TestEvmStateReaderVerifyCanonicalChain(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	for i := 0; i < 5; i++ {
+		env.ApplyBlock(sameEpoch)
+	}
+	last := uint64(env.store.GetLatestBlockIndex())
+
+	_, ok, err := env.stateReader.VerifyCanonicalChain(0, last)
+	require.NoError(err)
+	require.True(ok)
+
+	_, _, err = env.stateReader.VerifyCanonicalChain(last, last-1)
+	require.Error(err)
+
+	// blocks after the head are missing
+	gap, ok, err := env.stateReader.VerifyCanonicalChain(last-1, last+3)
+	require.NoError(err)
+	require.False(ok)
+	require.Equal(last+1, gap)
+
+	// a block isn't indexed by its hash
+	broken := idx.Block(last - 2)
+	env.store.SetBlockIndex(env.store.GetBlock(broken).Atropos, broken+100)
+	gap, ok, err = env.stateReader.VerifyCanonicalChain(0, last)
+	require.NoError(err)
+	require.False(ok)
+	require.Equal(uint64(broken), gap)
+	env.store.SetBlockIndex(env.store.GetBlock(broken).Atropos, broken)
+
+	// a block is missing
+	require.NoError(env.store.table.Blocks.Delete(broken.Bytes()))
+	env.store.cache.Blocks.Remove(broken)
+	gap, ok, err = env.stateReader.VerifyCanonicalChain(0, last)
+	require.NoError(err)
+	require.False(ok)
+	require.Equal(uint64(broken), gap)
+	// the header of the next block can't be built without the missing one
+	gap, ok, err = env.stateReader.VerifyCanonicalChain(uint64(broken)+1, last)
+	require.NoError(err)
+	require.False(ok)
+	require.Equal(uint64(broken)+1, gap)
+}
+
+func TestEvmStateReaderStateAtNoLimit(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := LiteStoreConfig()
+	cfg.EVM.MaxStateDBs = 1
+	store := NewStore(flushable.NewSyncedPool(memorydb.NewProducer(""), []byte{0}), cfg)
+	defer store.Close()
+	genStore := makegenesis.FakeGenesisStore(genesisStakers, utils.ToFtm(genesisBalance), utils.ToFtm(genesisStake))
+	_, err := store.ApplyGenesis(DefaultBlockProc(genStore.GetGenesis()), genStore.GetGenesis())
+	require.NoError(err)
+	reader := &EvmStateReader{store: store}
+	root := store.GetBlockState().FinalizedStateRoot
+
+	// an RPC call occupies the only slot
+	_, release, err := store.evm.StateDBCtx(context.Background(), root)
+	require.NoError(err)
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = store.evm.StateDBCtx(ctx, root)
+	require.Equal(context.DeadlineExceeded, err)
+
+	// the tx pool isn't limited
+	_, err = reader.StateAt(common.Hash(root))
+	require.NoError(err)
+}
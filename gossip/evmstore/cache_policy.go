@@ -0,0 +1,165 @@
+package evmstore
+
+import (
+	"sync"
+
+	"github.com/Fantom-foundation/lachesis-base/utils/wlru"
+)
+
+// CachePolicy is an eviction policy of a cache
+type CachePolicy string
+
+const (
+	// LRUCachePolicy evicts the least recently used entries
+	LRUCachePolicy CachePolicy = "lru"
+	// SLRUCachePolicy is a segmented LRU, which protects entries accessed more than once from being evicted by one-time entries
+	SLRUCachePolicy CachePolicy = "slru"
+)
+
+// slruProbationShare is a share of the segmented LRU capacity (in percents) for entries which were accessed only once
+const slruProbationShare = 20
+
+// weightedCache is a cache of weighted entries, independent of the eviction policy
+type weightedCache interface {
+	Add(key, value interface{}, weight uint) (evicted int)
+	Get(key interface{}) (value interface{}, ok bool)
+	Contains(key interface{}) bool
+	Remove(key interface{}) (present bool)
+	Keys() []interface{}
+	Len() int
+	Purge()
+}
+
+type slruEntry struct {
+	value  interface{}
+	weight uint
+}
+
+type slruDemoted struct {
+	key   interface{}
+	entry slruEntry
+}
+
+// slruCache is a segmented LRU cache. New entries are placed into the probationary segment,
+// and are promoted into the protected segment on the next access. Entries evicted from the
+// protected segment are demoted back into the probationary segment.
+type slruCache struct {
+	mu        sync.Mutex
+	probation *wlru.Cache
+	protected *wlru.Cache
+	// demoted collects entries evicted from the protected segment
+	demoted []slruDemoted
+}
+
+func newSLRUCache(maxWeight uint, maxSize int) (*slruCache, error) {
+	probationWeight, probationSize := maxWeight*slruProbationShare/100, maxSize*slruProbationShare/100
+	if probationWeight == 0 {
+		probationWeight = 1
+	}
+	if probationSize == 0 {
+		probationSize = 1
+	}
+	c := &slruCache{}
+	var err error
+	c.probation, err = wlru.New(probationWeight, probationSize)
+	if err != nil {
+		return nil, err
+	}
+	protectedWeight, protectedSize := maxWeight-probationWeight, maxSize-probationSize
+	if protectedWeight == 0 {
+		protectedWeight = 1
+	}
+	if protectedSize <= 0 {
+		protectedSize = 1
+	}
+	c.protected, err = wlru.NewWithEvict(protectedWeight, protectedSize, func(key interface{}, value interface{}) {
+		c.demoted = append(c.demoted, slruDemoted{key, value.(slruEntry)})
+	})
+	return c, err
+}
+
+// Add adds an entry into the probationary segment, or updates it in place.
+func (c *slruCache) Add(key, value interface{}, weight uint) (evicted int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := slruEntry{value, weight}
+	if c.protected.Contains(key) {
+		return c.addProtected(key, entry)
+	}
+	return c.probation.Add(key, entry, weight)
+}
+
+// Get returns an entry and promotes it into the protected segment.
+func (c *slruCache) Get(key interface{}) (value interface{}, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.protected.Get(key); ok {
+		return v.(slruEntry).value, true
+	}
+	v, ok := c.probation.Peek(key)
+	if !ok {
+		return nil, false
+	}
+	c.probation.Remove(key)
+	c.addProtected(key, v.(slruEntry))
+	return v.(slruEntry).value, true
+}
+
+// addProtected adds an entry into the protected segment and demotes the entries evicted from it.
+// Returns the number of entries evicted from the cache completely.
+func (c *slruCache) addProtected(key interface{}, entry slruEntry) (evicted int) {
+	c.demoted = c.demoted[:0]
+	c.protected.Add(key, entry, entry.weight)
+	demoted := c.demoted
+	c.demoted = nil
+	for _, d := range demoted {
+		evicted += c.probation.Add(d.key, d.entry, d.entry.weight)
+	}
+	return evicted
+}
+
+// Contains checks if the entry is in the cache, without updating the recency.
+func (c *slruCache) Contains(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.protected.Contains(key) || c.probation.Contains(key)
+}
+
+// Remove removes the entry from the cache.
+func (c *slruCache) Remove(key interface{}) (present bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	present = c.protected.Remove(key)
+	c.demoted = c.demoted[:0]
+	return c.probation.Remove(key) || present
+}
+
+// Keys returns the keys in the cache, from oldest to newest. Protected entries are considered newer.
+func (c *slruCache) Keys() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append(c.probation.Keys(), c.protected.Keys()...)
+}
+
+// Len returns the number of entries in the cache.
+func (c *slruCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.protected.Len() + c.probation.Len()
+}
+
+// Purge removes all the entries.
+func (c *slruCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.protected.Purge()
+	c.probation.Purge()
+	c.demoted = c.demoted[:0]
+}
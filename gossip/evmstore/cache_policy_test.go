@@ -0,0 +1,75 @@
+package evmstore
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLRUCache(t *testing.T) {
+	require := require.New(t)
+
+	cache, err := newSLRUCache(10, 10)
+	require.NoError(err)
+
+	cache.Add("hot", 1, 1)
+	v, ok := cache.Get("hot")
+	require.True(ok)
+	require.Equal(1, v)
+
+	// a scan of one-time entries doesn't evict the entry accessed twice
+	evicted := 0
+	for i := 0; i < 100; i++ {
+		evicted += cache.Add(i, i, 1)
+	}
+	require.Equal(100-2, evicted)
+	require.True(cache.Contains("hot"))
+	require.Equal(3, cache.Len())
+
+	// the entry evicted from the protected segment is demoted, not lost
+	for i := 0; i < 8; i++ {
+		cache.Add(fmt.Sprint("p", i), i, 1)
+		cache.Get(fmt.Sprint("p", i))
+	}
+	require.Equal(10, cache.Len())
+	require.True(cache.Contains("hot"))
+	require.True(cache.Remove("hot"))
+	require.False(cache.Contains("hot"))
+	require.Equal(9, cache.Len())
+
+	cache.Purge()
+	require.Equal(0, cache.Len())
+}
+
+func BenchmarkTxPositionsCachePolicy(b *testing.B) {
+	const size = 1000
+	for _, policy := range []CachePolicy{LRUCachePolicy, SLRUCachePolicy} {
+		b.Run(string(policy), func(b *testing.B) {
+			store := nonCachedStore()
+			cache := store.makePolicyCache(policy, size, size)
+			r := rand.New(rand.NewSource(0))
+			hits := 0
+			oneTime := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// recency-skewed trace: 70% of reads are for a hot set of recent txs,
+				// the rest are one-time reads of older txs
+				var key int
+				if r.Intn(10) < 7 {
+					key = r.Intn(size / 2)
+				} else {
+					oneTime++
+					key = size + oneTime
+				}
+				if _, ok := cache.Get(key); ok {
+					hits++
+				} else {
+					cache.Add(key, key, 1)
+				}
+			}
+			b.ReportMetric(float64(hits)/float64(b.N), "hits/read")
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package evmstore
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
+
 	"github.com/Fantom-foundation/lachesis-base/utils/cachescale"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 )
@@ -14,6 +18,8 @@ type (
 		ReceiptsBlocks int
 		// Cache size for TxPositions.
 		TxPositions int
+		// Eviction policy of TxPositions cache (LRU by default).
+		TxPositionsPolicy CachePolicy
 		// Cache size for EVM database.
 		EvmDatabase int
 		// Cache size for EVM snapshot.
@@ -22,30 +28,150 @@ type (
 		EvmBlocksNum int
 		// Cache size for EvmBlock (size in bytes).
 		EvmBlocksSize uint
+		// Cache size for logs queries results (number of queries), 0 disables the cache.
+		LogsQueriesNum int
+		// Cache size for logs queries results (total number of logs), 0 disables the cache.
+		LogsQueriesSize uint
+	}
+	// StoreTablesConfig is a config of the key prefixes of the store tables within the DB.
+	// Empty prefix means the default one.
+	StoreTablesConfig struct {
+		Receipts    string
+		TxPositions string
+		Txs         string
+		EpochBlocks string
+		BlockEpochs string
+		BlockBlooms string
+		// BlockValidators is a prefix of the block -> validator of the block Atropos index
+		BlockValidators string
+		// Evm is a prefix of the EVM state and snapshot
+		Evm string
+		// Logs is a prefix of the logs index
+		Logs string
 	}
 	// StoreConfig is a config for store db.
 	StoreConfig struct {
-		Cache           StoreCacheConfig
+		Cache StoreCacheConfig
+		// Tables are prefixes of the store tables, which must not overlap with other tables of the DB
+		Tables          StoreTablesConfig
 		EnableSnapshots bool
+		// SnapLayers is a number of the EVM snapshot diff layers retained on top of the disk layer,
+		// i.e. the number of the recent state roots which are served from snapshot rather than from trie besides the head one.
+		// Each diff layer keeps in memory all the accounts and storage slots modified by its block (0 means all the layers are flattened)
+		SnapLayers int
 		// Enables tracking of SHA3 preimages in the VM
 		EnablePreimageRecording bool
+		// MaxLogsPerQuery limits number of logs returned by a single logs query (0 means no limit)
+		MaxLogsPerQuery int
+		// WarmupReceiptsBlocks is a number of the most recent blocks which receipts are loaded into cache on startup (0 means no warmup)
+		WarmupReceiptsBlocks int
+		// ReceiptsDecodeWorkers is a max number of goroutines decoding receipts of a batch read in parallel, including the reading one.
+		// The helper goroutines are shared by all the batch reads, so concurrent reads don't multiply them (0 or 1 means sequential decoding)
+		ReceiptsDecodeWorkers int
+		// EnableBlockBlooms enables the per-block logs blooms index, which is used to skip non-matching blocks in logs queries.
+		// It speeds up wide range queries of rare logs of busy contracts, but slows down queries of rare contracts
+		EnableBlockBlooms bool
+		// LogsIndexBatchBlocks is a number of blocks which logs are indexed in one DB write while reindexing (0 or 1 means per-block writes)
+		LogsIndexBatchBlocks int
+		// MaxPausedLogs is a max number of logs buffered while the logs indexing is paused.
+		// On overflow, the buffered logs are indexed and the logs indexing is resumed with a warning, see PauseLogIndexing
+		MaxPausedLogs int
+		// MaxStateDBs is a max number of state databases acquired by StateDBCtx concurrently (0 means no limit).
+		// Only RPC calls acquire them, the tx pool and the internal callers use StateDB without a limit
+		MaxStateDBs int
+		// TrieCleanJournal is a directory where the clean trie nodes cache is saved on close and loaded from on start (empty means no journal).
+		// Environment variables and a leading "~" are expanded. The directory is created when the store is opened, it must be writable.
+		// The gossip store namespaces the directory by the network ID, so it may be shared by the networks
+		TrieCleanJournal string
 	}
 )
 
+// DefaultStoreTablesConfig returns the default prefixes of the store tables.
+func DefaultStoreTablesConfig() StoreTablesConfig {
+	return StoreTablesConfig{
+		Receipts:        "r",
+		TxPositions:     "x",
+		Txs:             "X",
+		EpochBlocks:     "E",
+		BlockEpochs:     "p",
+		BlockBlooms:     "f",
+		BlockValidators: "a",
+		Evm:             "M",
+		Logs:            "L",
+	}
+}
+
+// withDefaults replaces the empty prefixes with the default ones.
+func (c StoreTablesConfig) withDefaults() StoreTablesConfig {
+	def := DefaultStoreTablesConfig()
+	for _, p := range []struct {
+		prefix *string
+		def    string
+	}{
+		{&c.Receipts, def.Receipts},
+		{&c.TxPositions, def.TxPositions},
+		{&c.Txs, def.Txs},
+		{&c.EpochBlocks, def.EpochBlocks},
+		{&c.BlockEpochs, def.BlockEpochs},
+		{&c.BlockBlooms, def.BlockBlooms},
+		{&c.BlockValidators, def.BlockValidators},
+		{&c.Evm, def.Evm},
+		{&c.Logs, def.Logs},
+	} {
+		if *p.prefix == "" {
+			*p.prefix = p.def
+		}
+	}
+	return c
+}
+
+// Validate checks that none of the prefixes is a prefix of another one, i.e. the tables don't overlap.
+// Reserved are prefixes of the other tables sharing the DB, the tables mustn't overlap with them as well.
+func (c StoreTablesConfig) Validate(reserved ...string) error {
+	c = c.withDefaults()
+	prefixes := []string{c.Receipts, c.TxPositions, c.Txs, c.EpochBlocks, c.BlockEpochs, c.BlockBlooms, c.BlockValidators, c.Evm, c.Logs}
+	for i := range prefixes {
+		for j := range prefixes {
+			if i != j && strings.HasPrefix(prefixes[j], prefixes[i]) {
+				return fmt.Errorf("table prefixes '%s' and '%s' overlap", prefixes[i], prefixes[j])
+			}
+		}
+		for _, r := range reserved {
+			if strings.HasPrefix(prefixes[i], r) || strings.HasPrefix(r, prefixes[i]) {
+				return fmt.Errorf("table prefix '%s' overlaps with reserved prefix '%s'", prefixes[i], r)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the tables don't overlap.
+// The trie clean cache journal directory isn't checked, as it's created only when the store is opened.
+func (c StoreConfig) Validate() error {
+	return c.Tables.Validate()
+}
+
 // DefaultStoreConfig for product.
 func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 	return StoreConfig{
 		Cache: StoreCacheConfig{
-			ReceiptsSize:   scale.U(4 * opt.MiB),
-			ReceiptsBlocks: scale.I(4000),
-			TxPositions:    scale.I(20000),
-			EvmDatabase:    scale.I(32 * opt.MiB),
-			EvmSnap:        scale.I(32 * opt.MiB),
-			EvmBlocksNum:   scale.I(5000),
-			EvmBlocksSize:  scale.U(6 * opt.MiB),
+			ReceiptsSize:      scale.U(4 * opt.MiB),
+			ReceiptsBlocks:    scale.I(4000),
+			TxPositions:       scale.I(20000),
+			TxPositionsPolicy: LRUCachePolicy,
+			EvmDatabase:       scale.I(32 * opt.MiB),
+			EvmSnap:           scale.I(32 * opt.MiB),
+			EvmBlocksNum:      scale.I(5000),
+			EvmBlocksSize:     scale.U(6 * opt.MiB),
 		},
+		Tables:                  DefaultStoreTablesConfig(),
 		EnableSnapshots:         true,
 		EnablePreimageRecording: true,
+		MaxLogsPerQuery:         100000,
+		WarmupReceiptsBlocks:    scale.I(1000),
+		ReceiptsDecodeWorkers:   runtime.NumCPU(),
+		LogsIndexBatchBlocks:    100,
+		MaxPausedLogs:           scale.I(100000),
 	}
 }
 
@@ -59,7 +185,10 @@ func LiteStoreConfig() StoreConfig {
 			EvmBlocksNum:   100,
 			EvmBlocksSize:  3 * 1024,
 		},
+		Tables:                  DefaultStoreTablesConfig(),
 		EnableSnapshots:         true,
 		EnablePreimageRecording: true,
+		MaxLogsPerQuery:         1000,
+		MaxPausedLogs:           1000,
 	}
 }
@@ -2,6 +2,7 @@ package evmstore
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 
@@ -32,9 +34,17 @@ type Store struct {
 	mainDB kvdb.Store
 	table  struct {
 		// API-only tables
-		Receipts    kvdb.Store `table:"r"`
-		TxPositions kvdb.Store `table:"x"`
-		Txs         kvdb.Store `table:"X"`
+		Receipts    kvdb.Store
+		TxPositions kvdb.Store
+		Txs         kvdb.Store
+		// EpochBlocks is a sealed epoch -> sealing block index
+		EpochBlocks kvdb.Store
+		// BlockEpochs is a sealing block -> sealed epoch index
+		BlockEpochs kvdb.Store
+		// BlockBlooms is a block -> bloom of the block logs index, used to prefilter logs queries
+		BlockBlooms kvdb.Store
+		// BlockValidators is a block -> validator of the block Atropos index
+		BlockValidators kvdb.Store
 
 		Evm      ethdb.Database
 		EvmState state.Database
@@ -43,15 +53,43 @@ type Store struct {
 	}
 
 	cache struct {
-		TxPositions *wlru.Cache `cache:"-"` // store by pointer
-		Receipts    *wlru.Cache `cache:"-"` // store by value
-		EvmBlocks   *wlru.Cache `cache:"-"` // store by pointer
+		TxPositions weightedCache `cache:"-"` // store by pointer
+		Receipts    *wlru.Cache   `cache:"-"` // store by value
+		EvmBlocks   *wlru.Cache   `cache:"-"` // store by pointer
+		LogsQueries *wlru.Cache   `cache:"-"` // store by value
+	}
+
+	cacheEvictions struct {
+		TxPositions uint64
+		Receipts    uint64
+		EvmBlocks   uint64
 	}
 
 	mutex struct {
-		Inc sync.Mutex
+		Inc          sync.Mutex
+		LogsQueries  sync.Mutex
+		LogsIndexing sync.Mutex
+	}
+
+	// logsIndexGen is incremented every time logs are indexed, guarded by mutex.LogsQueries
+	logsIndexGen uint64
+
+	// logsPause is a state of the paused logs indexing, guarded by mutex.LogsIndexing
+	logsPause struct {
+		paused   bool
+		buffered []*types.Log
+		overflow bool
 	}
 
+	// stateDBs is a semaphore of the state databases acquired by StateDBCtx, nil means unlimited
+	stateDBs chan struct{}
+
+	// receiptsDecoders is a semaphore of the goroutines helping the batch receipts reads, nil means sequential decoding
+	receiptsDecoders chan struct{}
+
+	// closed is set once the store is closed, accessed atomically
+	closed uint32
+
 	rlp rlpstore.Helper
 
 	snaps *snapshot.Tree // Snapshot tree for fast trie leaf access
@@ -68,25 +106,75 @@ func NewStore(mainDB kvdb.Store, cfg StoreConfig) *Store {
 		rlp:      rlpstore.Helper{logger.MakeInstance()},
 	}
 
-	table.MigrateTables(&s.table, s.mainDB)
+	if err := cfg.Validate(); err != nil {
+		s.Log.Crit("Invalid EVM store config", "err", err)
+	}
+	s.cfg.Tables = cfg.Tables.withDefaults()
+	s.migrateTables(s.mainDB)
+
+	if cfg.TrieCleanJournal != "" {
+		dir, err := prepareJournalDir(cfg.TrieCleanJournal)
+		if err != nil {
+			s.Log.Crit("Invalid trie clean cache journal", "path", cfg.TrieCleanJournal, "err", err)
+		}
+		s.cfg.TrieCleanJournal = dir
+	}
 
 	evmTable := nokeyiserr.Wrap(s.EvmKvdbTable()) // ETH expects that "not found" is an error
 	s.table.Evm = rawdb.NewDatabase(kvdb2ethdb.Wrap(evmTable))
 	s.table.EvmState = state.NewDatabaseWithConfig(s.table.Evm, &trie.Config{
 		Cache:     cfg.Cache.EvmDatabase / opt.MiB,
+		Journal:   s.cfg.TrieCleanJournal,
 		Preimages: cfg.EnablePreimageRecording,
 	})
-	s.table.EvmLogs = topicsdb.New(table.New(s.mainDB, []byte("L")))
+
+	if !cfg.EnableBlockBlooms {
+		// the blooms index will have a gap, so it must be restarted if it's re-enabled
+		s.resetBlockBloomsStart()
+	}
 
 	s.initCache()
 
+	if cfg.MaxStateDBs > 0 {
+		s.stateDBs = make(chan struct{}, cfg.MaxStateDBs)
+	}
+	if cfg.ReceiptsDecodeWorkers > 1 {
+		// the calling goroutine decodes as well
+		s.receiptsDecoders = make(chan struct{}, cfg.ReceiptsDecodeWorkers-1)
+	}
+
 	return s
 }
 
+// migrateTables sets the tables over the db (nil db resets the tables).
+func (s *Store) migrateTables(db kvdb.Store) {
+	t := s.cfg.Tables
+	newTable := func(prefix string) kvdb.Store {
+		if db == nil {
+			return nil
+		}
+		return table.New(db, []byte(prefix))
+	}
+	s.table.Receipts = newTable(t.Receipts)
+	s.table.TxPositions = newTable(t.TxPositions)
+	s.table.Txs = newTable(t.Txs)
+	s.table.EpochBlocks = newTable(t.EpochBlocks)
+	s.table.BlockEpochs = newTable(t.BlockEpochs)
+	s.table.BlockBlooms = newTable(t.BlockBlooms)
+	s.table.BlockValidators = newTable(t.BlockValidators)
+	s.table.EvmLogs = nil
+	if db != nil {
+		s.table.EvmLogs = topicsdb.New(newTable(t.Logs))
+	}
+}
+
 func (s *Store) initCache() {
 	s.cache.Receipts = s.makeCache(s.cfg.Cache.ReceiptsSize, s.cfg.Cache.ReceiptsBlocks)
-	s.cache.TxPositions = s.makeCache(nominalSize*uint(s.cfg.Cache.TxPositions), s.cfg.Cache.TxPositions)
+	s.cache.TxPositions = s.makePolicyCache(s.cfg.Cache.TxPositionsPolicy, nominalSize*uint(s.cfg.Cache.TxPositions), s.cfg.Cache.TxPositions)
 	s.cache.EvmBlocks = s.makeCache(s.cfg.Cache.EvmBlocksSize, s.cfg.Cache.EvmBlocksNum)
+	if s.cfg.Cache.LogsQueriesNum > 0 && s.cfg.Cache.LogsQueriesSize > 0 {
+		s.cache.LogsQueries = s.makeCache(s.cfg.Cache.LogsQueriesSize, s.cfg.Cache.LogsQueriesNum)
+	}
 }
 
 func (s *Store) InitEvmSnapshot(root hash.Hash) (err error) {
@@ -94,6 +182,55 @@ func (s *Store) InitEvmSnapshot(root hash.Hash) (err error) {
 	return err
 }
 
+// Journal writes the data kept only in memory into the DB: the snapshot journal (stopping the snapshot generation),
+// the logs buffered by the paused logs indexing, and the trie clean cache journal file.
+// If the DB is flushable, Journal must be called before the final flush, otherwise the written data is dropped on close.
+// The snapshot is unusable after Journal, so it must be called only on shutdown. It's safe to call Journal multiple times.
+func (s *Store) Journal() error {
+	var err error
+	if s.table.Snaps != nil {
+		// journalling aborts the pending snapshot generation and persists its progress
+		_, err = s.table.Snaps.Journal(s.table.Snaps.DiskRoot())
+		if err != nil {
+			s.Log.Error("Failed to journal EVM snapshot", "err", err)
+		}
+		s.table.Snaps = nil
+	}
+
+	// the logs buffered by the paused logs indexing are flushed, the overflow was already reported
+	_ = s.ResumeLogIndexing()
+
+	if s.cfg.TrieCleanJournal != "" && s.table.EvmState != nil {
+		if jerr := s.table.EvmState.TrieDB().SaveCache(s.cfg.TrieCleanJournal); jerr != nil && err == nil {
+			err = jerr
+		}
+	}
+	return err
+}
+
+// Close journals the in-memory data, releases the store resources and closes the underlying DB.
+// It's safe to call Close multiple times.
+func (s *Store) Close() error {
+	if !atomic.CompareAndSwapUint32(&s.closed, 0, 1) {
+		return nil
+	}
+	err := s.Journal()
+
+	setnil := func() interface{} {
+		return nil
+	}
+
+	s.migrateTables(nil)
+	table.MigrateCaches(&s.cache, setnil)
+	s.table.Evm = nil
+	s.table.EvmState = nil
+
+	if cerr := s.mainDB.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	return err
+}
+
 // Commit changes.
 func (s *Store) Commit(root hash.Hash) error {
 	// Flush trie on the DB
@@ -115,19 +252,37 @@ func (s *Store) Cap(max, min int) {
 
 // StateDB returns state database.
 func (s *Store) StateDB(from hash.Hash) (*state.StateDB, error) {
-	return state.NewWithSnapLayers(common.Hash(from), s.table.EvmState, s.table.Snaps, 0)
+	return state.NewWithSnapLayers(common.Hash(from), s.table.EvmState, s.table.Snaps, s.cfg.SnapLayers)
 }
 
-// IndexLogs indexes EVM logs
+// IndexLogs indexes EVM logs.
+// The logs are buffered if the logs indexing is paused by PauseLogIndexing.
 func (s *Store) IndexLogs(recs ...*types.Log) {
+	s.mutex.LogsIndexing.Lock()
+	defer s.mutex.LogsIndexing.Unlock()
+
+	if s.logsPause.paused {
+		recs = s.bufferPausedLogs(recs)
+		if len(recs) == 0 {
+			return
+		}
+	}
+	s.indexLogs(recs)
+}
+
+func (s *Store) indexLogs(recs []*types.Log) {
 	err := s.table.EvmLogs.Push(recs...)
 	if err != nil {
 		s.Log.Crit("DB logs index error", "err", err)
 	}
+	if s.cfg.EnableBlockBlooms {
+		s.indexBlockBlooms(recs)
+	}
+	s.invalidateLogsQueries(recs)
 }
 
 func (s *Store) EvmKvdbTable() kvdb.Store {
-	return table.New(s.mainDB, []byte("M"))
+	return table.New(s.mainDB, []byte(s.cfg.Tables.Evm))
 }
 
 func (s *Store) EvmTable() ethdb.Database {
@@ -142,6 +297,67 @@ func (s *Store) EvmLogs() *topicsdb.Index {
 	return s.table.EvmLogs
 }
 
+// CacheStats is a usage statistics of a cache
+type CacheStats struct {
+	// Evictions is a number of entries evicted to free space for new ones
+	Evictions uint64
+}
+
+// CacheStats returns usage statistics of the caches, by cache name
+func (s *Store) CacheStats() map[string]CacheStats {
+	return map[string]CacheStats{
+		"TxPositions": {Evictions: atomic.LoadUint64(&s.cacheEvictions.TxPositions)},
+		"Receipts":    {Evictions: atomic.LoadUint64(&s.cacheEvictions.Receipts)},
+		"EvmBlocks":   {Evictions: atomic.LoadUint64(&s.cacheEvictions.EvmBlocks)},
+	}
+}
+
+// TrieDBStats is a diagnostic snapshot of the EVM trie DB.
+// There's no TriesInMemory window or triegc queue to report, as the state of every block is committed
+// into the trie DB right away and the dirty nodes are flushed by size only, see Store.Cap.
+type TrieDBStats struct {
+	// DirtyNodes is a number of trie nodes which aren't flushed into DB yet
+	DirtyNodes int `json:"dirtyNodes"`
+	// DirtySize is a size of the dirty trie nodes
+	DirtySize common.StorageSize `json:"dirtySize"`
+	// PreimagesSize is a size of the preimages which aren't flushed into DB yet
+	PreimagesSize common.StorageSize `json:"preimagesSize"`
+	// CleanHits is a number of the trie nodes read from the clean cache
+	CleanHits int64 `json:"cleanHits"`
+	// CleanMisses is a number of the trie nodes missed in the clean cache
+	CleanMisses int64 `json:"cleanMisses"`
+	// CleanHitRate is a ratio of CleanHits to all the clean cache reads, zero if there were no reads
+	CleanHitRate float64 `json:"cleanHitRate"`
+}
+
+// TrieDBStats returns memory usage statistics of the EVM trie DB.
+// It iterates over all the dirty nodes, so it's intended only for debugging.
+// The clean cache counters are the process-wide trie metrics, they're zero unless metrics are enabled.
+func (s *Store) TrieDBStats() TrieDBStats {
+	trieDB := s.table.EvmState.TrieDB()
+	dirtySize, preimagesSize := trieDB.Size()
+	stats := TrieDBStats{
+		DirtyNodes:    len(trieDB.Nodes()),
+		DirtySize:     dirtySize,
+		PreimagesSize: preimagesSize,
+		CleanHits:     meterCount("trie/memcache/clean/hit"),
+		CleanMisses:   meterCount("trie/memcache/clean/miss"),
+	}
+	if reads := stats.CleanHits + stats.CleanMisses; reads != 0 {
+		stats.CleanHitRate = float64(stats.CleanHits) / float64(reads)
+	}
+	return stats
+}
+
+// meterCount returns the count of the registered meter, zero if it isn't registered
+func meterCount(name string) int64 {
+	meter, ok := metrics.DefaultRegistry.Get(name).(metrics.Meter)
+	if !ok {
+		return 0
+	}
+	return meter.Count()
+}
+
 /*
  * Utils:
  */
@@ -154,3 +370,28 @@ func (s *Store) makeCache(weight uint, size int) *wlru.Cache {
 	}
 	return cache
 }
+
+func (s *Store) makePolicyCache(policy CachePolicy, weight uint, size int) weightedCache {
+	switch policy {
+	case "", LRUCachePolicy:
+		return s.makeCache(weight, size)
+	case SLRUCachePolicy:
+		cache, err := newSLRUCache(weight, size)
+		if err != nil {
+			s.Log.Crit("Failed to create SLRU cache", "err", err)
+			return nil
+		}
+		return cache
+	}
+	s.Log.Crit("Unknown cache policy", "policy", policy)
+	return nil
+}
+
+// addToCache adds value to the cache and counts entries which were evicted by it.
+// Note: explicitly removed entries aren't counted as evicted
+func addToCache(cache weightedCache, evictions *uint64, key, value interface{}, weight uint) {
+	evicted := cache.Add(key, value, weight)
+	if evicted != 0 {
+		atomic.AddUint64(evictions, uint64(evicted))
+	}
+}
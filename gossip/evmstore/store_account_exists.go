@@ -0,0 +1,37 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Exists checks if the account is present in the state at the root, without decoding the account.
+// Note: a present account may be empty according to EIP-161 (zero nonce, zero balance and no code),
+// e.g. if it was committed without deletion of empty objects. Such account is reported as present,
+// unlike state.StateDB.Empty which treats it as nonexistent.
+// The snapshot is used if it's available, the trie is used otherwise.
+func (s *Store) Exists(root hash.Hash, addr common.Address) (bool, error) {
+	enc, _, err := s.accountRLP(root, addr)
+	return len(enc) != 0, err
+}
+
+// accountRLP returns the encoded account at the root, or nil if it doesn't exist.
+// The encoding is slim if the account is read from the snapshot, and full if it's read from the trie.
+func (s *Store) accountRLP(root hash.Hash, addr common.Address) (enc []byte, slim bool, err error) {
+	if s.table.Snaps != nil {
+		if snap := s.table.Snaps.Snapshot(common.Hash(root)); snap != nil {
+			enc, err := snap.AccountRLP(crypto.Keccak256Hash(addr.Bytes()))
+			if err == nil {
+				return enc, true, nil
+			}
+			// fall back to the trie if the snapshot is stale or isn't generated yet
+		}
+	}
+	tr, err := s.table.EvmState.OpenTrie(common.Hash(root))
+	if err != nil {
+		return nil, false, err
+	}
+	enc, err = tr.TryGet(addr.Bytes())
+	return enc, false, err
+}
@@ -0,0 +1,54 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreExists(t *testing.T) {
+	logger.SetTestMode(t)
+
+	present, empty, absent := common.Address{1}, common.Address{2}, common.Address{3}
+	for name, snap := range map[string]bool{"trie": false, "snapshot": true} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := LiteStoreConfig()
+			cfg.Cache.EvmSnap = 1024 * 1024
+			store := NewStore(memorydb.New(), cfg)
+			statedb, err := store.StateDB(hash.Hash{})
+			require.NoError(err)
+			statedb.AddBalance(present, big.NewInt(1))
+			statedb.CreateAccount(empty)
+			// keep the empty account
+			root, err := statedb.Commit(false)
+			require.NoError(err)
+			require.NoError(store.Commit(hash.Hash(root)))
+			if snap {
+				require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+			}
+
+			ok, err := store.Exists(hash.Hash(root), present)
+			require.NoError(err)
+			require.True(ok)
+
+			ok, err = store.Exists(hash.Hash(root), empty)
+			require.NoError(err)
+			require.True(ok)
+
+			ok, err = store.Exists(hash.Hash(root), absent)
+			require.NoError(err)
+			require.False(ok)
+
+			_, err = store.Exists(hash.Hash{1}, present)
+			require.Error(err)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// blockBloomsStartKey is a key of the first block indexed by the blooms index.
+// It's shorter than block keys, so it doesn't collide with them.
+var blockBloomsStartKey = []byte("s")
+
+// indexBlockBlooms adds the logs into the blooms of their blocks
+func (s *Store) indexBlockBlooms(recs []*types.Log) {
+	blooms := make(map[idx.Block]types.Bloom)
+	for _, l := range recs {
+		n := idx.Block(l.BlockNumber)
+		bloom, ok := blooms[n]
+		if !ok {
+			bloom = s.getBlockBloom(n)
+		}
+		bloom.Add(l.Address.Bytes())
+		for _, topic := range l.Topics {
+			bloom.Add(topic.Bytes())
+		}
+		blooms[n] = bloom
+	}
+	for n, bloom := range blooms {
+		if start, ok := s.getBlockBloomsStart(); !ok || n < start {
+			s.setBlockBloomsStart(n)
+		}
+		if err := s.table.BlockBlooms.Put(n.Bytes(), bloom.Bytes()); err != nil {
+			s.Log.Crit("Failed to put key-value", "err", err)
+		}
+	}
+}
+
+func (s *Store) getBlockBloom(n idx.Block) types.Bloom {
+	buf, err := s.table.BlockBlooms.Get(n.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	return types.BytesToBloom(buf)
+}
+
+func (s *Store) getBlockBloomsStart() (idx.Block, bool) {
+	buf, err := s.table.BlockBlooms.Get(blockBloomsStartKey)
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if buf == nil {
+		return 0, false
+	}
+	return idx.BytesToBlock(buf), true
+}
+
+func (s *Store) setBlockBloomsStart(n idx.Block) {
+	if err := s.table.BlockBlooms.Put(blockBloomsStartKey, n.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+func (s *Store) resetBlockBloomsStart() {
+	if _, ok := s.getBlockBloomsStart(); !ok {
+		return
+	}
+	if err := s.table.BlockBlooms.Delete(blockBloomsStartKey); err != nil {
+		s.Log.Crit("Failed to erase key-value", "err", err)
+	}
+}
+
+// patternBlooms returns the single-entry blooms of the pattern variants. 1st pattern element is an address.
+// Empty positions are skipped, as they match any log.
+func patternBlooms(pattern [][]common.Hash) [][]types.Bloom {
+	res := make([][]types.Bloom, 0, len(pattern))
+	for i, variants := range pattern {
+		if len(variants) == 0 {
+			continue
+		}
+		blooms := make([]types.Bloom, len(variants))
+		for j, v := range variants {
+			data := v.Bytes()
+			if i == 0 {
+				data = common.BytesToAddress(data).Bytes()
+			}
+			blooms[j].Add(data)
+		}
+		res = append(res, blooms)
+	}
+	return res
+}
+
+// bloomMatches checks if the block bloom may contain logs matched by the pattern blooms.
+func bloomMatches(bloom []byte, pattern [][]types.Bloom) bool {
+	for _, variants := range pattern {
+		matched := false
+		for _, v := range variants {
+			if bloomContains(bloom, v) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func bloomContains(bloom []byte, sub types.Bloom) bool {
+	if len(bloom) != types.BloomByteLength {
+		return false
+	}
+	for i, b := range sub {
+		if bloom[i]&b != b {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachBlocksRangeByBlooms calls onRange for the ranges of the blocks which may contain logs matched by the pattern.
+// Blocks below the start of the blooms index are passed without filtering.
+func (s *Store) forEachBlocksRangeByBlooms(from, to idx.Block, pattern [][]common.Hash, onRange func(from, to idx.Block) (gonext bool, err error)) error {
+	start, ok := s.getBlockBloomsStart()
+	if !ok || start > to {
+		_, err := onRange(from, to)
+		return err
+	}
+	if from < start {
+		gonext, err := onRange(from, start-1)
+		if !gonext || err != nil {
+			return err
+		}
+		from = start
+	}
+
+	blooms := patternBlooms(pattern)
+	// consecutive matched blocks are merged into a single range,
+	// blocks without logs aren't indexed and don't break the range
+	it := s.table.BlockBlooms.NewIterator(nil, from.Bytes())
+	defer it.Release()
+	var (
+		rangeFrom, rangeTo idx.Block
+		inRange            bool
+	)
+	for it.Next() {
+		if len(it.Key()) != len(from.Bytes()) {
+			continue
+		}
+		n := idx.BytesToBlock(it.Key())
+		if n > to {
+			break
+		}
+		if bloomMatches(it.Value(), blooms) {
+			if !inRange {
+				rangeFrom, inRange = n, true
+			}
+			rangeTo = n
+			continue
+		}
+		if inRange {
+			inRange = false
+			gonext, err := onRange(rangeFrom, rangeTo)
+			if !gonext || err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if inRange {
+		_, err := onRange(rangeFrom, rangeTo)
+		return err
+	}
+	return nil
+}
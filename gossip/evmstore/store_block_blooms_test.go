@@ -0,0 +1,152 @@
+package evmstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+var (
+	busyAddr  = common.Address{0xbb}
+	rareAddr  = common.Address{0xff}
+	rareTopic = common.Hash{0xff}
+)
+
+// sparseLogs returns perBlock logs of the busy contract in every block.
+// Every sparse-th block has also a busy contract log with the rare topic, and a log of the rare contract.
+func sparseLogs(blocks, perBlock, sparse int) []*types.Log {
+	logs := make([]*types.Log, 0, blocks*perBlock)
+	for b := 1; b <= blocks; b++ {
+		for i := 0; i < perBlock; i++ {
+			logs = append(logs, &types.Log{
+				Address:     busyAddr,
+				Topics:      []common.Hash{{byte(i % 3)}},
+				BlockNumber: uint64(b),
+				TxHash:      common.BigToHash(big.NewInt(int64(b))),
+				Index:       uint(i),
+			})
+		}
+		if b%sparse == 0 {
+			for i, addr := range []common.Address{busyAddr, rareAddr} {
+				logs = append(logs, &types.Log{
+					Address:     addr,
+					Topics:      []common.Hash{rareTopic},
+					BlockNumber: uint64(b),
+					TxHash:      common.BigToHash(big.NewInt(int64(b))),
+					Index:       uint(perBlock + i),
+				})
+			}
+		}
+	}
+	return logs
+}
+
+func TestStoreFindLogsByBlockBlooms(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	cfg := LiteStoreConfig()
+	store := NewStore(db, cfg)
+	logs := sparseLogs(100, 3, 10)
+	// first blocks are indexed before the blooms index is enabled
+	i := 0
+	for ; logs[i].BlockNumber <= 15; i++ {
+		store.IndexLogs(logs[i])
+	}
+	cfg.EnableBlockBlooms = true
+	store = NewStore(db, cfg)
+	for ; i < len(logs); i++ {
+		store.IndexLogs(logs[i])
+	}
+	start, ok := store.getBlockBloomsStart()
+	require.True(ok)
+	require.Equal(idx.Block(16), start)
+
+	for _, pattern := range [][][]common.Hash{
+		{{rareAddr.Hash()}},
+		{{rareAddr.Hash()}, {rareTopic}},
+		{{rareAddr.Hash()}, {{1}}},
+		{{busyAddr.Hash()}, {rareTopic}},
+		{nil, {{2}}},
+		{{common.Address{0xee}.Hash(), rareAddr.Hash()}},
+	} {
+		for _, r := range [][2]idx.Block{{1, 100}, {12, 55}, {30, 30}, {1, 9}} {
+			withBlooms, err := store.FindLogsInBlocksLimited(context.Background(), r[0], r[1], pattern, 0)
+			require.NoError(err)
+			expect, err := store.EvmLogs().FindInBlocks(context.Background(), r[0], r[1], pattern)
+			require.NoError(err)
+			require.ElementsMatch(expect, withBlooms, fmt.Sprintf("range %v, pattern %v", r, pattern))
+		}
+	}
+
+	got, err := store.FindLogsInBlocksLimited(context.Background(), 1, 100, [][]common.Hash{{rareAddr.Hash()}}, 0)
+	require.NoError(err)
+	require.Len(got, 10)
+
+	_, err = store.FindLogsInBlocksLimited(context.Background(), 1, 100, [][]common.Hash{{rareAddr.Hash()}}, 5)
+	require.True(errors.Is(err, ErrTooManyLogs))
+
+	// the index is restarted after it's disabled
+	cfg.EnableBlockBlooms = false
+	NewStore(db, cfg)
+	cfg.EnableBlockBlooms = true
+	store = NewStore(db, cfg)
+	_, ok = store.getBlockBloomsStart()
+	require.False(ok)
+}
+
+// BenchmarkStoreFindLogsWideRange compares a wide range query with and without the blooms prefilter.
+// The prefilter pays off if the first pattern position is common, but the whole pattern is rare (busy contract + rare topic),
+// and it's an overhead if the first pattern position is rare itself (rare contract).
+func BenchmarkStoreFindLogsWideRange(b *testing.B) {
+	logger.SetTestMode(b)
+
+	const (
+		blocks = 20000
+		sparse = 1000
+	)
+	logs := sparseLogs(blocks, 5, sparse)
+
+	for _, blooms := range []bool{false, true} {
+		b.Run(fmt.Sprintf("blooms=%v", blooms), func(b *testing.B) {
+			// flushable has a sorted in-memory index, unlike memorydb which sorts keys on every iterator creation
+			db := flushable.Wrap(memorydb.New())
+			cfg := LiteStoreConfig()
+			cfg.EnableBlockBlooms = blooms
+			store := NewStore(db, cfg)
+			store.IndexLogs(logs...)
+
+			for _, q := range []struct {
+				name    string
+				pattern [][]common.Hash
+			}{
+				{"busy contract rare topic", [][]common.Hash{{busyAddr.Hash()}, {rareTopic}}},
+				{"rare contract", [][]common.Hash{{rareAddr.Hash()}}},
+			} {
+				b.Run(q.name, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						got, err := store.FindLogsInBlocksLimited(context.Background(), 1, blocks, q.pattern, 0)
+						if err != nil {
+							b.Fatal(err)
+						}
+						if len(got) != blocks/sparse {
+							b.Fatalf("unexpected number of logs: %d", len(got))
+						}
+					}
+				})
+			}
+		})
+	}
+}
@@ -21,5 +21,5 @@ func (s *Store) SetCachedEvmBlock(n idx.Block, b *evmcore.EvmBlock) {
 	if b.EvmHeader.TxHash == empty {
 		panic("You have to cache only completed blocks (with txs)")
 	}
-	s.cache.EvmBlocks.Add(n, b, uint(b.EstimateSize()))
+	addToCache(s.cache.EvmBlocks, &s.cacheEvictions.EvmBlocks, n, b, uint(b.EstimateSize()))
 }
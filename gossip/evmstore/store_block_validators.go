@@ -0,0 +1,25 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+// SetBlockValidator stores the validator which created the Atropos event of the block.
+func (s *Store) SetBlockValidator(n idx.Block, validator idx.ValidatorID) {
+	if err := s.table.BlockValidators.Put(n.Bytes(), validator.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// GetBlockValidator returns the validator which created the Atropos event of the block.
+// Returns false if the block isn't processed yet, was processed before the index was introduced, or is a genesis block.
+func (s *Store) GetBlockValidator(n uint64) (idx.ValidatorID, bool) {
+	buf, err := s.table.BlockValidators.Get(idx.Block(n).Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if buf == nil {
+		return 0, false
+	}
+	return idx.BytesToValidatorID(buf), true
+}
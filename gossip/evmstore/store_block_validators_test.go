@@ -0,0 +1,31 @@
+package evmstore
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetBlockValidator(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	// blocks before 3 are processed before the index was introduced
+	for n := idx.Block(3); n <= 10; n++ {
+		store.SetBlockValidator(n, idx.ValidatorID(n%4+1))
+	}
+
+	for n := uint64(0); n <= 12; n++ {
+		got, ok := store.GetBlockValidator(n)
+		if n < 3 || n > 10 {
+			require.False(ok, n)
+			continue
+		}
+		require.True(ok, n)
+		require.Equal(idx.ValidatorID(n%4+1), got, n)
+	}
+}
@@ -0,0 +1,65 @@
+package evmstore
+
+import (
+	"context"
+	"io"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+)
+
+// CacheKeys are keys of the cached entries, from oldest to newest
+type CacheKeys struct {
+	Receipts    []idx.Block
+	TxPositions []common.Hash
+	EvmBlocks   []idx.Block
+}
+
+// GetCacheKeys returns keys of the cached receipts, txs positions and EVM blocks.
+func (s *Store) GetCacheKeys() CacheKeys {
+	var keys CacheKeys
+	for _, k := range s.cache.Receipts.Keys() {
+		keys.Receipts = append(keys.Receipts, k.(idx.Block))
+	}
+	for _, k := range s.cache.TxPositions.Keys() {
+		keys.TxPositions = append(keys.TxPositions, common.HexToHash(k.(string)))
+	}
+	for _, k := range s.cache.EvmBlocks.Keys() {
+		keys.EvmBlocks = append(keys.EvmBlocks, k.(idx.Block))
+	}
+	return keys
+}
+
+// DumpCacheKeys writes keys of the cached receipts, txs positions and EVM blocks, to warm the caches up after restart.
+func (s *Store) DumpCacheKeys(w io.Writer) error {
+	return rlp.Encode(w, s.GetCacheKeys())
+}
+
+// WarmCachesFromKeys loads the entries with keys written by DumpCacheKeys into the caches.
+// It's best-effort, keys which aren't in DB anymore are skipped.
+// EVM blocks aren't stored by the store, so they are loaded with evmBlock (nil evmBlock means EVM blocks are skipped).
+func (s *Store) WarmCachesFromKeys(r io.Reader, evmBlock func(idx.Block) *evmcore.EvmBlock) error {
+	var keys CacheKeys
+	if err := rlp.Decode(r, &keys); err != nil {
+		return err
+	}
+	// entries are added to the caches on reading, in the same order as they were added originally
+	if _, err := s.getReceiptsBatch(context.Background(), keys.Receipts); err != nil {
+		return err
+	}
+	for _, txid := range keys.TxPositions {
+		s.GetTxPosition(txid)
+	}
+	if evmBlock == nil {
+		return nil
+	}
+	for _, n := range keys.EvmBlocks {
+		if b := evmBlock(n); b != nil {
+			s.SetCachedEvmBlock(n, b)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package evmstore
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreCacheKeysRoundTrip(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	store := NewStore(db, LiteStoreConfig())
+	_, receipts := fakeReceipts()
+	evmBlock := func(n idx.Block) *evmcore.EvmBlock {
+		return evmcore.NewEvmBlock(&evmcore.EvmHeader{Number: big.NewInt(int64(n))}, nil)
+	}
+	for n := idx.Block(1); n <= 5; n++ {
+		store.SetReceipts(n, receipts)
+		store.SetTxPosition(common.Hash{byte(n)}, TxPosition{Block: n})
+		store.SetCachedEvmBlock(n, evmBlock(n))
+	}
+	// cold entries
+	store.cache.Receipts.Remove(idx.Block(1))
+	store.cache.TxPositions.Remove(common.Hash{1}.String())
+
+	buf := new(bytes.Buffer)
+	require.NoError(store.DumpCacheKeys(buf))
+
+	// pruned entry
+	require.NoError(store.table.Receipts.Delete(idx.Block(2).Bytes()))
+
+	restarted := NewStore(db, LiteStoreConfig())
+	var loaded []idx.Block
+	require.NoError(restarted.WarmCachesFromKeys(buf, func(n idx.Block) *evmcore.EvmBlock {
+		loaded = append(loaded, n)
+		return evmBlock(n)
+	}))
+
+	require.Equal([]idx.Block{1, 2, 3, 4, 5}, loaded)
+	for n := idx.Block(1); n <= 5; n++ {
+		require.Equal(n > 2, restarted.cache.Receipts.Contains(n), n)
+		require.Equal(n > 1, restarted.cache.TxPositions.Contains(common.Hash{byte(n)}.String()), n)
+		require.True(restarted.cache.EvmBlocks.Contains(n), n)
+	}
+	require.Equal(store.GetCacheKeys().TxPositions, restarted.GetCacheKeys().TxPositions)
+}
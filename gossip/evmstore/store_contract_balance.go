@@ -0,0 +1,39 @@
+package evmstore
+
+import (
+	"math/big"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// GetContractBalance returns balance of the account at the state root, or 0 if the account doesn't exist.
+// Only the account is read, unlike state.StateDB it doesn't load the contract code or storage.
+//
+// Note: the node doesn't transfer rewards into the SFC contract at the epoch sealing. Rewards are
+// computed by the SFC contract and are minted only when they're claimed, so the SFC balance is the
+// sum of the stakes and of the minted but not yet withdrawn rewards, not the pending reward pool.
+func (s *Store) GetContractBalance(root hash.Hash, addr common.Address) (*big.Int, error) {
+	enc, slim, err := s.accountRLP(root, addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return new(big.Int), nil
+	}
+	if slim {
+		var acc snapshot.Account
+		if err := rlp.DecodeBytes(enc, &acc); err != nil {
+			return nil, err
+		}
+		return acc.Balance, nil
+	}
+	var acc state.Account
+	if err := rlp.DecodeBytes(enc, &acc); err != nil {
+		return nil, err
+	}
+	return acc.Balance, nil
+}
@@ -0,0 +1,62 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetContractBalance(t *testing.T) {
+	logger.SetTestMode(t)
+
+	contract, absent := common.Address{1}, common.Address{2}
+	for name, snap := range map[string]bool{"trie": false, "snapshot": true} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := LiteStoreConfig()
+			cfg.Cache.EvmSnap = 1024 * 1024
+			store := NewStore(memorydb.New(), cfg)
+			statedb, err := store.StateDB(hash.Hash{})
+			require.NoError(err)
+			statedb.AddBalance(contract, big.NewInt(100))
+			statedb.SetCode(contract, []byte{0x60, 0x00})
+			statedb.SetState(contract, common.Hash{1}, common.Hash{2})
+			root1, err := statedb.Commit(true)
+			require.NoError(err)
+			require.NoError(store.Commit(hash.Hash(root1)))
+
+			statedb, err = store.StateDB(hash.Hash(root1))
+			require.NoError(err)
+			statedb.AddBalance(contract, big.NewInt(50))
+			root2, err := statedb.Commit(true)
+			require.NoError(err)
+			require.NoError(store.Commit(hash.Hash(root2)))
+			if snap {
+				require.NoError(store.InitEvmSnapshot(hash.Hash(root2)))
+			}
+
+			// historical balance
+			balance, err := store.GetContractBalance(hash.Hash(root1), contract)
+			require.NoError(err)
+			require.Equal(big.NewInt(100), balance)
+
+			balance, err = store.GetContractBalance(hash.Hash(root2), contract)
+			require.NoError(err)
+			require.Equal(big.NewInt(150), balance)
+
+			balance, err = store.GetContractBalance(hash.Hash(root2), absent)
+			require.NoError(err)
+			require.Equal(0, balance.Sign())
+
+			_, err = store.GetContractBalance(hash.Hash{1}, contract)
+			require.Error(err)
+		})
+	}
+}
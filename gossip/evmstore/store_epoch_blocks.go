@@ -0,0 +1,39 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+// SetEpochSealBlock stores the block which sealed the epoch.
+func (s *Store) SetEpochSealBlock(epoch idx.Epoch, n idx.Block) {
+	if err := s.table.EpochBlocks.Put(epoch.Bytes(), n.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+	if err := s.table.BlockEpochs.Put(n.Bytes(), epoch.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// GetEpochSealBlock returns the block which sealed the epoch.
+// Returns false if the epoch isn't sealed yet, or was sealed before the index was introduced.
+func (s *Store) GetEpochSealBlock(epoch idx.Epoch) (uint64, bool) {
+	buf, err := s.table.EpochBlocks.Get(epoch.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if buf == nil {
+		return 0, false
+	}
+	return uint64(idx.BytesToBlock(buf)), true
+}
+
+// BlockEpoch returns the epoch of the block, i.e. the epoch sealed by the first sealing block not lower than n.
+// Returns false if the block belongs to a not sealed epoch.
+func (s *Store) BlockEpoch(n uint64) (idx.Epoch, bool) {
+	it := s.table.BlockEpochs.NewIterator(nil, idx.Block(n).Bytes())
+	defer it.Release()
+	if !it.Next() {
+		return 0, false
+	}
+	return idx.BytesToEpoch(it.Value()), true
+}
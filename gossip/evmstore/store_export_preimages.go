@@ -0,0 +1,88 @@
+package evmstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// ErrPreimageMismatch is returned if an imported preimage doesn't match its hash
+	ErrPreimageMismatch = errors.New("preimage doesn't match hash")
+
+	// preimagePrefix is a prefix of the preimages within the EVM table, it's the same as the rawdb one
+	preimagePrefix = []byte("secure-key-")
+)
+
+// preimageRecord is an exported preimage of a hashed trie key
+type preimageRecord struct {
+	Hash     common.Hash
+	Preimage []byte
+}
+
+// ExportPreimages writes the recorded preimages into w, as the RLP stream of records.
+// Only the preimages flushed into DB are exported, i.e. ones of the committed states.
+// Nothing is written if preimages recording is disabled.
+func (s *Store) ExportPreimages(w io.Writer) error {
+	if !s.cfg.EnablePreimageRecording {
+		return nil
+	}
+	it := s.table.Evm.NewIterator(preimagePrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if len(it.Key()) != len(preimagePrefix)+common.HashLength {
+			continue
+		}
+		rec := preimageRecord{
+			Hash:     common.BytesToHash(it.Key()[len(preimagePrefix):]),
+			Preimage: it.Value(),
+		}
+		if err := rlp.Encode(w, &rec); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// ImportPreimages writes the preimages exported by ExportPreimages into DB.
+// ErrPreimageMismatch is returned if a preimage doesn't match its hash, the preimages before it are written.
+// Nothing is read if preimages recording is disabled.
+func (s *Store) ImportPreimages(r io.Reader) error {
+	if !s.cfg.EnablePreimageRecording {
+		return nil
+	}
+	stream := rlp.NewStream(r, 0)
+	batch := s.table.Evm.NewBatch()
+	defer batch.Reset()
+	for {
+		var rec preimageRecord
+		err := stream.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if crypto.Keccak256Hash(rec.Preimage) != rec.Hash {
+			if werr := batch.Write(); werr != nil {
+				return werr
+			}
+			return fmt.Errorf("%w: %s", ErrPreimageMismatch, rec.Hash.String())
+		}
+		if err := batch.Put(append(common.CopyBytes(preimagePrefix), rec.Hash.Bytes()...), rec.Preimage); err != nil {
+			return err
+		}
+		if batch.ValueSize() > kvdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	return batch.Write()
+}
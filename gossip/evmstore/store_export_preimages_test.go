@@ -0,0 +1,70 @@
+package evmstore
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreExportPreimages(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := LiteStoreConfig()
+	cfg.EnablePreimageRecording = true
+	store := NewStore(memorydb.New(), cfg)
+
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	var preimages [][]byte
+	for i := int64(1); i <= 5; i++ {
+		addr := common.BigToAddress(big.NewInt(i))
+		key := common.BigToHash(big.NewInt(i * 100))
+		statedb.AddBalance(addr, big.NewInt(i))
+		statedb.SetState(addr, key, common.BigToHash(big.NewInt(i)))
+		preimages = append(preimages, addr.Bytes(), key.Bytes())
+	}
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(store.Commit(hash.Hash(root)))
+
+	buf := &bytes.Buffer{}
+	require.NoError(store.ExportPreimages(buf))
+	exported := buf.Bytes()
+
+	imported := NewStore(memorydb.New(), cfg)
+	for _, preimage := range preimages {
+		_, ok := imported.GetPreimage(crypto.Keccak256Hash(preimage))
+		require.False(ok)
+	}
+	require.NoError(imported.ImportPreimages(bytes.NewReader(exported)))
+	for _, preimage := range preimages {
+		got, ok := imported.GetPreimage(crypto.Keccak256Hash(preimage))
+		require.True(ok)
+		require.Equal(preimage, got)
+	}
+
+	// preimages are verified
+	corrupted := &bytes.Buffer{}
+	require.NoError(rlp.Encode(corrupted, &preimageRecord{Hash: common.Hash{1}, Preimage: []byte{1}}))
+	err = NewStore(memorydb.New(), cfg).ImportPreimages(corrupted)
+	require.True(errors.Is(err, ErrPreimageMismatch))
+
+	// skipped if recording is disabled
+	cfg.EnablePreimageRecording = false
+	disabled := NewStore(memorydb.New(), cfg)
+	require.NoError(disabled.ImportPreimages(bytes.NewReader(exported)))
+	buf.Reset()
+	require.NoError(disabled.ExportPreimages(buf))
+	require.Zero(buf.Len())
+}
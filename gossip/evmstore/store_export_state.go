@@ -0,0 +1,249 @@
+package evmstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// importCommitEvery is a number of imported accounts after which the imported tries are flushed into DB
+const importCommitEvery = 10000
+
+var (
+	// ErrStateRootMismatch is returned if the imported state doesn't match the exported state root
+	ErrStateRootMismatch = errors.New("imported state root mismatch")
+
+	emptyCodeHash = crypto.Keccak256Hash(nil)
+)
+
+// stateRecord is an exported account with its code and storage
+type stateRecord struct {
+	// Hash is the hash of the account address
+	Hash common.Hash
+	// Account is the consensus RLP encoding of the account
+	Account []byte
+	Code    []byte
+	Storage []storageRecord
+}
+
+// storageRecord is an exported storage slot
+type storageRecord struct {
+	// Hash is the hash of the slot key
+	Hash common.Hash
+	// Value is the RLP encoding of the slot value
+	Value []byte
+}
+
+// ExportState writes the state at the root into w, as the RLP stream of the root followed by a record per account.
+// Accounts are keyed by address hashes, so preimages aren't required.
+// Note: it iterates the whole state, and storage of an account is held in memory while it's written.
+// The snapshot is iterated if it's available, the tries are iterated otherwise.
+func (s *Store) ExportState(root hash.Hash, w io.Writer) error {
+	if err := rlp.Encode(w, root); err != nil {
+		return err
+	}
+	if s.table.Snaps != nil {
+		accIt, err := s.table.Snaps.AccountIterator(common.Hash(root), common.Hash{})
+		if err == nil {
+			return s.exportSnapshotState(common.Hash(root), accIt, w)
+		}
+	}
+	return s.exportTrieState(common.Hash(root), w)
+}
+
+func (s *Store) exportSnapshotState(root common.Hash, accIt snapshot.AccountIterator, w io.Writer) error {
+	defer accIt.Release()
+	for accIt.Next() {
+		full, err := snapshot.FullAccountRLP(accIt.Account())
+		if err != nil {
+			return err
+		}
+		rec, acc, err := s.newStateRecord(accIt.Hash(), full)
+		if err != nil {
+			return err
+		}
+		if acc.Root != types.EmptyRootHash {
+			stIt, err := s.table.Snaps.StorageIterator(root, accIt.Hash(), common.Hash{})
+			if err != nil {
+				return err
+			}
+			for stIt.Next() {
+				if len(stIt.Slot()) == 0 {
+					// deleted slot
+					continue
+				}
+				rec.Storage = append(rec.Storage, storageRecord{stIt.Hash(), common.CopyBytes(stIt.Slot())})
+			}
+			err = stIt.Error()
+			stIt.Release()
+			if err != nil {
+				return err
+			}
+		}
+		if err := rlp.Encode(w, rec); err != nil {
+			return err
+		}
+	}
+	return accIt.Error()
+}
+
+func (s *Store) exportTrieState(root common.Hash, w io.Writer) error {
+	tr, err := s.table.EvmState.OpenTrie(root)
+	if err != nil {
+		return err
+	}
+	accIt := trie.NewIterator(tr.NodeIterator(nil))
+	for accIt.Next() {
+		addrHash := common.BytesToHash(accIt.Key)
+		rec, acc, err := s.newStateRecord(addrHash, accIt.Value)
+		if err != nil {
+			return err
+		}
+		if acc.Root != types.EmptyRootHash {
+			st, err := s.table.EvmState.OpenStorageTrie(addrHash, acc.Root)
+			if err != nil {
+				return err
+			}
+			stIt := trie.NewIterator(st.NodeIterator(nil))
+			for stIt.Next() {
+				rec.Storage = append(rec.Storage, storageRecord{common.BytesToHash(stIt.Key), stIt.Value})
+			}
+			if stIt.Err != nil {
+				return stIt.Err
+			}
+		}
+		if err := rlp.Encode(w, rec); err != nil {
+			return err
+		}
+	}
+	return accIt.Err
+}
+
+// newStateRecord makes a record of the account with its code, but without storage
+func (s *Store) newStateRecord(addrHash common.Hash, full []byte) (*stateRecord, *state.Account, error) {
+	var acc state.Account
+	if err := rlp.DecodeBytes(full, &acc); err != nil {
+		return nil, nil, err
+	}
+	rec := &stateRecord{
+		Hash:    addrHash,
+		Account: common.CopyBytes(full),
+	}
+	if codeHash := common.BytesToHash(acc.CodeHash); codeHash != emptyCodeHash {
+		code, err := s.table.EvmState.ContractCode(addrHash, codeHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("code of account %s: %w", addrHash.String(), err)
+		}
+		rec.Code = code
+	}
+	return rec, &acc, nil
+}
+
+// ImportState rebuilds the state exported by ExportState, and flushes it into DB.
+// The state root is verified after import, ErrStateRootMismatch is returned if it mismatches.
+// Note: the imported tries are flushed every importCommitEvery accounts, so storage of a single
+// account has to fit into memory. The snapshot isn't updated.
+func (s *Store) ImportState(r io.Reader) (hash.Hash, error) {
+	stream := rlp.NewStream(r, 0)
+	var root common.Hash
+	if err := stream.Decode(&root); err != nil {
+		return hash.Zero, err
+	}
+
+	trieDB := s.table.EvmState.TrieDB()
+	tr, err := trie.New(common.Hash{}, trieDB)
+	if err != nil {
+		return hash.Zero, err
+	}
+	imported := 0
+	for {
+		var rec stateRecord
+		err := stream.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return hash.Zero, err
+		}
+		if err := s.importStateRecord(tr, &rec); err != nil {
+			return hash.Zero, err
+		}
+		imported++
+		if imported%importCommitEvery == 0 {
+			tr, err = s.flushImportedTrie(tr)
+			if err != nil {
+				return hash.Zero, err
+			}
+		}
+	}
+	tr, err = s.flushImportedTrie(tr)
+	if err != nil {
+		return hash.Zero, err
+	}
+	if tr.Hash() != root {
+		return hash.Hash(tr.Hash()), fmt.Errorf("%w: expected %s, got %s", ErrStateRootMismatch, root.String(), tr.Hash().String())
+	}
+	return hash.Hash(root), nil
+}
+
+func (s *Store) importStateRecord(tr *trie.Trie, rec *stateRecord) error {
+	var acc state.Account
+	if err := rlp.DecodeBytes(rec.Account, &acc); err != nil {
+		return err
+	}
+	if len(rec.Code) != 0 {
+		if codeHash := crypto.Keccak256Hash(rec.Code); !bytes.Equal(codeHash.Bytes(), acc.CodeHash) {
+			return fmt.Errorf("code hash mismatch of account %s", rec.Hash.String())
+		}
+		rawdb.WriteCode(s.table.Evm, common.BytesToHash(acc.CodeHash), rec.Code)
+	}
+	if len(rec.Storage) != 0 {
+		st, err := trie.New(common.Hash{}, s.table.EvmState.TrieDB())
+		if err != nil {
+			return err
+		}
+		for _, slot := range rec.Storage {
+			if err := st.TryUpdate(slot.Hash.Bytes(), slot.Value); err != nil {
+				return err
+			}
+		}
+		if _, err := st.Commit(nil); err != nil {
+			return err
+		}
+	}
+	return tr.TryUpdate(rec.Hash.Bytes(), rec.Account)
+}
+
+// flushImportedTrie commits the accounts trie along with the referenced storage tries into DB,
+// and returns the trie reopened at the committed root
+func (s *Store) flushImportedTrie(tr *trie.Trie) (*trie.Trie, error) {
+	trieDB := s.table.EvmState.TrieDB()
+	var acc state.Account
+	root, err := tr.Commit(func(_ [][]byte, _ []byte, leaf []byte, parent common.Hash) error {
+		if err := rlp.DecodeBytes(leaf, &acc); err != nil {
+			return nil
+		}
+		if acc.Root != types.EmptyRootHash {
+			trieDB.Reference(acc.Root, parent)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := trieDB.Commit(root, false, nil); err != nil {
+		return nil, err
+	}
+	return trie.New(root, trieDB)
+}
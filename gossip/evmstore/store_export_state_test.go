@@ -0,0 +1,70 @@
+package evmstore
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreExportImportState(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := LiteStoreConfig()
+	cfg.Cache.EvmSnap = 1024 * 1024
+	store := NewStore(memorydb.New(), cfg)
+
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	for i := int64(1); i <= 10; i++ {
+		addr := common.BigToAddress(big.NewInt(i))
+		statedb.AddBalance(addr, big.NewInt(i))
+		statedb.SetNonce(addr, uint64(i))
+		if i%3 == 0 {
+			statedb.SetCode(addr, []byte{0x60, byte(i)})
+			for j := int64(1); j <= i; j++ {
+				statedb.SetState(addr, common.BigToHash(big.NewInt(j)), common.BigToHash(big.NewInt(i*j)))
+			}
+		}
+	}
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(store.Commit(hash.Hash(root)))
+
+	roundTrip := func(name string) {
+		buf := &bytes.Buffer{}
+		require.NoError(store.ExportState(hash.Hash(root), buf), name)
+
+		imported := NewStore(memorydb.New(), LiteStoreConfig())
+		importedRoot, err := imported.ImportState(bytes.NewReader(buf.Bytes()))
+		require.NoError(err, name)
+		require.Equal(hash.Hash(root), importedRoot, name)
+
+		importedState, err := imported.StateDB(importedRoot)
+		require.NoError(err, name)
+		addr := common.BigToAddress(big.NewInt(9))
+		require.Equal(big.NewInt(9), importedState.GetBalance(addr), name)
+		require.Equal([]byte{0x60, 9}, importedState.GetCode(addr), name)
+		require.Equal(common.BigToHash(big.NewInt(81)), importedState.GetState(addr, common.BigToHash(big.NewInt(9))), name)
+
+		// the stream with a wrong root
+		wrong, err := rlp.EncodeToBytes(common.Hash{1})
+		require.NoError(err)
+		wrong = append(wrong, buf.Bytes()[len(wrong):]...)
+		_, err = NewStore(memorydb.New(), LiteStoreConfig()).ImportState(bytes.NewReader(wrong))
+		require.True(errors.Is(err, ErrStateRootMismatch), name)
+	}
+
+	roundTrip("trie")
+	require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+	roundTrip("snapshot")
+}
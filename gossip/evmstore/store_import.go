@@ -0,0 +1,103 @@
+package evmstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+)
+
+var (
+	// ErrReceiptsMismatch is returned if a block is imported with a wrong number of receipts
+	ErrReceiptsMismatch = errors.New("receipts don't match block transactions")
+)
+
+// batchedDB reads from the DB, and buffers all the writes into a single batch.
+// Reads don't see the buffered writes.
+type batchedDB struct {
+	kvdb.Store
+	batch kvdb.Batch
+}
+
+func (db *batchedDB) Put(key []byte, value []byte) error {
+	return db.batch.Put(key, value)
+}
+
+func (db *batchedDB) Delete(key []byte) error {
+	return db.batch.Delete(key)
+}
+
+// withDB returns a store over the db, which has the same config as s, but its own caches.
+func (s *Store) withDB(db kvdb.Store) *Store {
+	tmp := &Store{
+		cfg:      s.cfg,
+		mainDB:   db,
+		Instance: s.Instance,
+		rlp:      s.rlp,
+	}
+	tmp.migrateTables(db)
+	tmp.initCache()
+	return tmp
+}
+
+// ImportBlock persists the block receipts, positions of the block transactions and the logs index in one DB batch,
+// and then updates the caches. Nothing is persisted if the batch fails.
+// Transactions positions are known only within the block, event positions are left empty.
+// The block itself is only cached, as it's persisted by the gossip store.
+func (s *Store) ImportBlock(block *evmcore.EvmBlock, receipts types.Receipts) error {
+	if len(receipts) != len(block.Transactions) {
+		return fmt.Errorf("%w: %d txs, %d receipts", ErrReceiptsMismatch, len(block.Transactions), len(receipts))
+	}
+	n := idx.Block(block.Number.Uint64())
+
+	db := &batchedDB{
+		Store: s.mainDB,
+		batch: s.mainDB.NewBatch(),
+	}
+	defer db.batch.Reset()
+	batched := s.withDB(db)
+
+	positions := make([]TxPosition, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		positions[i] = TxPosition{
+			Block:       n,
+			BlockOffset: uint32(i),
+		}
+		batched.rlp.Set(batched.table.TxPositions, tx.Hash().Bytes(), &positions[i])
+	}
+
+	var (
+		size int
+		logs []*types.Log
+	)
+	if len(receipts) != 0 {
+		receiptsStorage := make([]*types.ReceiptForStorage, len(receipts))
+		for i, r := range receipts {
+			receiptsStorage[i] = (*types.ReceiptForStorage)(r)
+			logs = append(logs, r.Logs...)
+		}
+		size = batched.SetRawReceipts(n, receiptsStorage)
+		batched.IndexLogs(logs...)
+	}
+
+	if err := db.batch.Write(); err != nil {
+		return err
+	}
+
+	for i, tx := range block.Transactions {
+		addToCache(s.cache.TxPositions, &s.cacheEvictions.TxPositions, tx.Hash().String(), &positions[i], nominalSize)
+	}
+	if len(receipts) != 0 {
+		s.invalidateLogsQueries(logs)
+		addToCache(s.cache.Receipts, &s.cacheEvictions.Receipts, n, receipts, uint(size))
+	}
+	if block.TxHash != (common.Hash{}) {
+		s.SetCachedEvmBlock(n, block)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package evmstore
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+var errBatchWrite = errors.New("batch write failed")
+
+// failingBatchDB is a DB which fails to write batches
+type failingBatchDB struct {
+	*memorydb.Database
+}
+
+type failingBatch struct {
+	kvdb.Batch
+}
+
+func (db failingBatchDB) NewBatch() kvdb.Batch {
+	return failingBatch{db.Database.NewBatch()}
+}
+
+func (b failingBatch) Write() error {
+	return errBatchWrite
+}
+
+func fakeImportedBlock() (*evmcore.EvmBlock, types.Receipts) {
+	txs := types.Transactions{
+		types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(0), nil),
+		types.NewTransaction(1, common.Address{2}, big.NewInt(1), 30000, big.NewInt(0), nil),
+	}
+	block := evmcore.NewEvmBlock(&evmcore.EvmHeader{
+		Number: big.NewInt(5),
+		Hash:   common.Hash{5},
+	}, txs)
+	receipts := make(types.Receipts, len(txs))
+	for i, tx := range txs {
+		receipts[i] = &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			TxHash:            tx.Hash(),
+			Logs: []*types.Log{{
+				Address:     common.Address{0xaa},
+				Topics:      []common.Hash{{byte(i)}},
+				BlockNumber: 5,
+				BlockHash:   block.Hash,
+				TxHash:      tx.Hash(),
+				TxIndex:     uint(i),
+				Index:       uint(i),
+			}},
+		}
+	}
+	return block, receipts
+}
+
+func TestStoreImportBlock(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	store := NewStore(db, LiteStoreConfig())
+	block, receipts := fakeImportedBlock()
+
+	require.True(errors.Is(store.ImportBlock(block, receipts[:1]), ErrReceiptsMismatch))
+
+	require.NoError(store.ImportBlock(block, receipts))
+	require.Equal(block, store.GetCachedEvmBlock(5))
+
+	// read back from DB, bypassing the caches
+	for _, s := range []*Store{store, NewStore(db, LiteStoreConfig())} {
+		for i, tx := range block.Transactions {
+			require.Equal(&TxPosition{Block: 5, BlockOffset: uint32(i)}, s.GetTxPosition(tx.Hash()))
+		}
+		got := s.GetReceipts(5)
+		require.Len(got, 2)
+		for i := range receipts {
+			require.Equal(receipts[i].Status, got[i].Status)
+			require.Equal(receipts[i].CumulativeGasUsed, got[i].CumulativeGasUsed)
+		}
+		logs, err := s.FindLogsInBlocks(context.Background(), 5, 5, [][]common.Hash{{common.Address{0xaa}.Hash()}})
+		require.NoError(err)
+		require.Len(logs, 2)
+	}
+}
+
+func TestStoreImportBlockRollback(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := failingBatchDB{memorydb.New()}
+	store := NewStore(db, LiteStoreConfig())
+	block, receipts := fakeImportedBlock()
+
+	require.Equal(errBatchWrite, store.ImportBlock(block, receipts))
+
+	require.Nil(store.GetCachedEvmBlock(5))
+	for _, tx := range block.Transactions {
+		require.Nil(store.GetTxPosition(tx.Hash()))
+	}
+	require.Nil(store.GetReceipts(5))
+	logs, err := store.FindLogsInBlocks(context.Background(), 5, 5, [][]common.Hash{{common.Address{0xaa}.Hash()}})
+	require.NoError(err)
+	require.Len(logs, 0)
+}
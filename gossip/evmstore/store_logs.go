@@ -0,0 +1,150 @@
+package evmstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	// ErrTooManyLogs is returned if a logs query matches more records than allowed
+	ErrTooManyLogs = errors.New("too many logs matched, narrow the blocks range")
+)
+
+// CompactLogsIndex compacts the key range of the logs index table only.
+// It doesn't change the data, so it's safe to call concurrently with logs queries.
+func (s *Store) CompactLogsIndex() error {
+	r := util.BytesPrefix([]byte(s.cfg.Tables.Logs))
+	return s.mainDB.Compact(r.Start, r.Limit)
+}
+
+// FindLogsInBlocks returns log records of block range by pattern. 1st pattern element is an address.
+// If StoreConfig.EnableBlockBlooms is set, blocks are prefiltered by the blooms index.
+// Result size is limited by StoreConfig.MaxLogsPerQuery.
+func (s *Store) FindLogsInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash) ([]*types.Log, error) {
+	return s.FindLogsInBlocksLimited(ctx, from, to, pattern, s.cfg.MaxLogsPerQuery)
+}
+
+// FindLogsInBlocksLimited is the same as FindLogsInBlocks, but with a custom result size limit.
+// Zero limit means no limit, which is intended only for trusted internal callers.
+// Results are cached if the logs queries cache is enabled by StoreCacheConfig.
+func (s *Store) FindLogsInBlocksLimited(ctx context.Context, from, to idx.Block, pattern [][]common.Hash, limit int) ([]*types.Log, error) {
+	if s.cache.LogsQueries == nil {
+		return s.findLogsInBlocks(ctx, from, to, pattern, limit)
+	}
+	key := logsQueryKey(from, to, pattern, limit)
+	if cached, ok := s.getLogsQuery(key); ok {
+		return cached, nil
+	}
+	gen := s.getLogsIndexGen()
+	logs, err := s.findLogsInBlocks(ctx, from, to, pattern, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.addLogsQuery(key, from, to, gen, logs)
+	return logs, nil
+}
+
+func (s *Store) findLogsInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash, limit int) (logs []*types.Log, err error) {
+	exceeded := false
+	onLog := func(l *types.Log) bool {
+		if limit > 0 && len(logs) >= limit {
+			exceeded = true
+			return false
+		}
+		logs = append(logs, l)
+		return true
+	}
+	if s.cfg.EnableBlockBlooms {
+		err = s.forEachBlocksRangeByBlooms(from, to, pattern, func(from, to idx.Block) (bool, error) {
+			err := s.table.EvmLogs.ForEachInBlocks(ctx, from, to, pattern, onLog)
+			return !exceeded, err
+		})
+	} else {
+		err = s.table.EvmLogs.ForEachInBlocks(ctx, from, to, pattern, onLog)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exceeded {
+		return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyLogs, limit)
+	}
+	return logs, nil
+}
+
+// GetLogsByAddresses returns log records of block range emitted by any of addresses and matched by topics pattern.
+// Empty addresses list matches any address. Result is ordered by block and log index, without duplicates.
+func (s *Store) GetLogsByAddresses(ctx context.Context, addrs []common.Address, from, to idx.Block, topics [][]common.Hash) ([]*types.Log, error) {
+	logs, err := s.FindLogsInBlocks(ctx, from, to, addressesPattern(addrs, topics))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	unique := logs[:0]
+	for i, l := range logs {
+		if i > 0 && l.BlockNumber == logs[i-1].BlockNumber && l.Index == logs[i-1].Index {
+			continue
+		}
+		unique = append(unique, l)
+	}
+	return unique, nil
+}
+
+// CountLogs returns the number of log records of block range emitted by any of addresses and matched by topics pattern.
+// Empty addresses list matches any address. The number is limited by StoreConfig.MaxLogsPerQuery,
+// the counting stops and ErrTooManyLogs is returned once it's exceeded.
+// If StoreConfig.EnableBlockBlooms is set, blocks are prefiltered by the blooms index.
+func (s *Store) CountLogs(ctx context.Context, addrs []common.Address, from, to idx.Block, topics [][]common.Hash) (count uint64, err error) {
+	pattern := addressesPattern(addrs, topics)
+	limit := uint64(0)
+	if s.cfg.MaxLogsPerQuery > 0 {
+		limit = uint64(s.cfg.MaxLogsPerQuery)
+	}
+	if s.cfg.EnableBlockBlooms {
+		err = s.forEachBlocksRangeByBlooms(from, to, pattern, func(from, to idx.Block) (bool, error) {
+			// a single record over the limit is enough to detect the overflow, zero means no limit though
+			left := uint64(0)
+			if limit > 0 {
+				left = limit - count + 1
+			}
+			n, err := s.table.EvmLogs.CountInBlocks(ctx, from, to, pattern, left)
+			count += n
+			return limit == 0 || count <= limit, err
+		})
+	} else {
+		count, err = s.table.EvmLogs.CountInBlocks(ctx, from, to, pattern, limit)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if limit > 0 && count > limit {
+		return 0, fmt.Errorf("%w, the limit is %d", ErrTooManyLogs, limit)
+	}
+	return count, nil
+}
+
+// addressesPattern makes a logs pattern of the unique addresses followed by topics
+func addressesPattern(addrs []common.Address, topics [][]common.Hash) [][]common.Hash {
+	pattern := make([][]common.Hash, 1, len(topics)+1)
+	seen := make(map[common.Address]bool, len(addrs))
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		pattern[0] = append(pattern[0], addr.Hash())
+	}
+	return append(pattern, topics...)
+}
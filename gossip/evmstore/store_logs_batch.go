@@ -0,0 +1,25 @@
+package evmstore
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// IndexLogsBatch indexes EVM logs of multiple blocks in one DB write.
+// Logs queries don't see any of the logs until all of them are written.
+func (s *Store) IndexLogsBatch(blocksLogs ...[]*types.Log) {
+	batch := s.table.EvmLogs.BeginBatch()
+	var recs []*types.Log
+	for _, logs := range blocksLogs {
+		if err := batch.Push(logs...); err != nil {
+			s.Log.Crit("DB logs index error", "err", err)
+		}
+		recs = append(recs, logs...)
+	}
+	if err := batch.Commit(); err != nil {
+		s.Log.Crit("DB logs index error", "err", err)
+	}
+	if s.cfg.EnableBlockBlooms {
+		s.indexBlockBlooms(recs)
+	}
+	s.invalidateLogsQueries(recs)
+}
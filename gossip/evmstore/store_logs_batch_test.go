@@ -0,0 +1,52 @@
+package evmstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreIndexLogsBatch(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := LiteStoreConfig()
+	cfg.EnableBlockBlooms = true
+	perBlock := NewStore(memorydb.New(), cfg)
+	batched := NewStore(memorydb.New(), cfg)
+
+	const blocks = 20
+	blocksLogs := make([][]*types.Log, blocks+1)
+	for _, l := range sparseLogs(blocks, 3, 4) {
+		blocksLogs[l.BlockNumber] = append(blocksLogs[l.BlockNumber], l)
+	}
+	for _, logs := range blocksLogs {
+		perBlock.IndexLogs(logs...)
+	}
+	batched.IndexLogsBatch(blocksLogs[:blocks/2]...)
+	batched.IndexLogsBatch(blocksLogs[blocks/2:]...)
+
+	for _, pattern := range [][][]common.Hash{
+		{{busyAddr.Hash()}},
+		{{rareAddr.Hash()}},
+		{{}, {rareTopic}},
+	} {
+		exp, err := perBlock.FindLogsInBlocks(ctx, 1, blocks, pattern)
+		require.NoError(err)
+		got, err := batched.FindLogsInBlocks(ctx, 1, blocks, pattern)
+		require.NoError(err)
+		require.NotEmpty(got)
+		require.Equal(exp, got)
+	}
+	for n := idx.Block(1); n <= blocks; n++ {
+		require.Equal(perBlock.getBlockBloom(n), batched.getBlockBloom(n), n)
+	}
+}
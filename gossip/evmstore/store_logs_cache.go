@@ -0,0 +1,108 @@
+package evmstore
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type logsQueryResult struct {
+	from, to idx.Block
+	logs     []*types.Log
+}
+
+// logsQueryKey returns a key of the logs query, which is the same for equivalent patterns,
+// i.e. the order and duplicates of the hashes within a pattern position don't matter.
+func logsQueryKey(from, to idx.Block, pattern [][]common.Hash, limit int) string {
+	// trailing wildcards don't change the query
+	for len(pattern) > 0 && len(pattern[len(pattern)-1]) == 0 {
+		pattern = pattern[:len(pattern)-1]
+	}
+	var key bytes.Buffer
+	key.Write(from.Bytes())
+	key.Write(to.Bytes())
+	key.Write(idx.Block(limit).Bytes())
+	for _, variants := range pattern {
+		sorted := make([]common.Hash, len(variants))
+		copy(sorted, variants)
+		sort.Slice(sorted, func(i, j int) bool {
+			return bytes.Compare(sorted[i].Bytes(), sorted[j].Bytes()) < 0
+		})
+		// position separator, distinct from a hash
+		key.WriteByte(0)
+		for i, h := range sorted {
+			if i > 0 && h == sorted[i-1] {
+				continue
+			}
+			key.WriteByte(1)
+			key.Write(h.Bytes())
+		}
+	}
+	return key.String()
+}
+
+func (s *Store) getLogsIndexGen() uint64 {
+	s.mutex.LogsQueries.Lock()
+	defer s.mutex.LogsQueries.Unlock()
+	return s.logsIndexGen
+}
+
+// getLogsQuery returns a copy of the cached logs query result
+func (s *Store) getLogsQuery(key string) ([]*types.Log, bool) {
+	c, ok := s.cache.LogsQueries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	cached := c.(logsQueryResult).logs
+	return append(make([]*types.Log, 0, len(cached)), cached...), true
+}
+
+// addLogsQuery caches the logs query result, unless logs were indexed since the gen was read,
+// as the result may be missing some of them.
+func (s *Store) addLogsQuery(key string, from, to idx.Block, gen uint64, logs []*types.Log) {
+	res := logsQueryResult{
+		from: from,
+		to:   to,
+		logs: append(make([]*types.Log, 0, len(logs)), logs...),
+	}
+
+	s.mutex.LogsQueries.Lock()
+	defer s.mutex.LogsQueries.Unlock()
+	if gen != s.logsIndexGen {
+		return
+	}
+	s.cache.LogsQueries.Add(key, res, uint(len(logs))+1)
+}
+
+// invalidateLogsQueries drops the cached logs queries results which may be affected by the new logs
+func (s *Store) invalidateLogsQueries(recs []*types.Log) {
+	if s.cache.LogsQueries == nil || len(recs) == 0 {
+		return
+	}
+	first, last := recs[0].BlockNumber, recs[0].BlockNumber
+	for _, r := range recs[1:] {
+		if r.BlockNumber < first {
+			first = r.BlockNumber
+		}
+		if r.BlockNumber > last {
+			last = r.BlockNumber
+		}
+	}
+
+	s.mutex.LogsQueries.Lock()
+	defer s.mutex.LogsQueries.Unlock()
+	s.logsIndexGen++
+	for _, key := range s.cache.LogsQueries.Keys() {
+		c, ok := s.cache.LogsQueries.Peek(key)
+		if !ok {
+			continue
+		}
+		res := c.(logsQueryResult)
+		if uint64(res.to) >= first && uint64(res.from) <= last {
+			s.cache.LogsQueries.Remove(key)
+		}
+	}
+}
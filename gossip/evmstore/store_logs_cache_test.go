@@ -0,0 +1,86 @@
+package evmstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreLogsQueriesCache(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := LiteStoreConfig()
+	cfg.Cache.LogsQueriesNum = 10
+	cfg.Cache.LogsQueriesSize = 1000
+	store := NewStore(memorydb.New(), cfg)
+
+	addr1, addr2 := common.Address{1}, common.Address{2}
+	store.IndexLogs(fakeLogs(addr1, 5, 2)...)
+	// pushes logs bypassing the cache invalidation, to detect cache hits
+	pushUncached := func(logs ...*types.Log) {
+		require.NoError(store.table.EvmLogs.Push(logs...))
+	}
+	fakeLog := func(addr common.Address, block uint64) *types.Log {
+		l := fakeLogs(addr, 1, 1)[0]
+		l.BlockNumber = block
+		return l
+	}
+
+	pattern := [][]common.Hash{{addr1.Hash(), addr2.Hash()}}
+	got, err := store.FindLogsInBlocks(ctx, 1, 3, pattern)
+	require.NoError(err)
+	require.Len(got, 6)
+	// the result is a copy
+	got[0] = nil
+
+	// hit, including an equivalent pattern
+	pushUncached(fakeLog(addr2, 2))
+	for _, p := range [][][]common.Hash{
+		pattern,
+		{{addr2.Hash(), addr1.Hash(), addr1.Hash()}},
+		{{addr1.Hash(), addr2.Hash()}, {}},
+	} {
+		got, err = store.FindLogsInBlocks(ctx, 1, 3, p)
+		require.NoError(err)
+		require.Len(got, 6)
+		require.NotNil(got[0])
+	}
+
+	// miss on a different range or pattern
+	got, err = store.FindLogsInBlocks(ctx, 1, 4, pattern)
+	require.NoError(err)
+	require.Len(got, 9)
+	got, err = store.FindLogsInBlocks(ctx, 1, 3, [][]common.Hash{{addr2.Hash()}})
+	require.NoError(err)
+	require.Len(got, 1)
+
+	// logs beyond the range don't invalidate the result
+	store.IndexLogs(fakeLog(addr1, 6))
+	got, err = store.FindLogsInBlocks(ctx, 1, 3, pattern)
+	require.NoError(err)
+	require.Len(got, 6)
+
+	// logs within the range invalidate the result
+	store.IndexLogs(fakeLog(addr1, 3))
+	got, err = store.FindLogsInBlocks(ctx, 1, 3, pattern)
+	require.NoError(err)
+	require.Len(got, 8)
+
+	// a range beyond the indexed blocks is invalidated by the new blocks
+	got, err = store.FindLogsInBlocks(ctx, 5, 10, pattern)
+	require.NoError(err)
+	require.Len(got, 3)
+	store.IndexLogs(fakeLog(addr2, 7))
+	got, err = store.FindLogsInBlocks(ctx, 5, 10, pattern)
+	require.NoError(err)
+	require.Len(got, 4)
+
+}
@@ -0,0 +1,74 @@
+package evmstore
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrPausedLogsOverflow is returned on resume if the logs indexing was resumed earlier because the paused logs buffer overflowed
+	ErrPausedLogsOverflow = errors.New("paused logs buffer overflowed, logs indexing was resumed")
+)
+
+// PauseLogIndexing pauses the logs indexing, e.g. to reduce the DB contention during a heavy reindexing.
+// Logs passed to IndexLogs are buffered until ResumeLogIndexing or FlushPausedLogs is called,
+// and logs queries don't see them meanwhile. The buffer is kept in memory only, so the owner of a flushable DB
+// must call FlushPausedLogs before every DB flush, otherwise the buffered logs are lost on a crash.
+// Logs indexed by IndexLogsBatch and ImportBlock aren't buffered.
+//
+// If more than StoreConfig.MaxPausedLogs logs get buffered, IndexLogs doesn't fail: it indexes the buffered logs,
+// resumes the logs indexing and logs a warning, so no logs are dropped. The overflow is reported by
+// the next ResumeLogIndexing call, which returns ErrPausedLogsOverflow.
+func (s *Store) PauseLogIndexing() {
+	s.mutex.LogsIndexing.Lock()
+	defer s.mutex.LogsIndexing.Unlock()
+
+	s.logsPause.paused = true
+}
+
+// ResumeLogIndexing indexes the logs buffered while the logs indexing was paused, and resumes the logs indexing.
+// Returns ErrPausedLogsOverflow if the buffer overflowed since the last PauseLogIndexing call.
+func (s *Store) ResumeLogIndexing() error {
+	s.mutex.LogsIndexing.Lock()
+	defer s.mutex.LogsIndexing.Unlock()
+
+	buffered, overflow := s.logsPause.buffered, s.logsPause.overflow
+	s.logsPause.paused = false
+	s.logsPause.buffered = nil
+	s.logsPause.overflow = false
+	if len(buffered) != 0 {
+		s.IndexLogsBatch(buffered)
+	}
+	if overflow {
+		return ErrPausedLogsOverflow
+	}
+	return nil
+}
+
+// FlushPausedLogs indexes the logs buffered while the logs indexing is paused, the logs indexing stays paused.
+func (s *Store) FlushPausedLogs() {
+	s.mutex.LogsIndexing.Lock()
+	defer s.mutex.LogsIndexing.Unlock()
+
+	buffered := s.logsPause.buffered
+	s.logsPause.buffered = nil
+	if len(buffered) != 0 {
+		s.IndexLogsBatch(buffered)
+	}
+}
+
+// bufferPausedLogs buffers the logs while the logs indexing is paused.
+// If the buffer overflows, the logs indexing is resumed and all the buffered logs are returned to be indexed.
+func (s *Store) bufferPausedLogs(recs []*types.Log) []*types.Log {
+	if len(s.logsPause.buffered)+len(recs) <= s.cfg.MaxPausedLogs {
+		s.logsPause.buffered = append(s.logsPause.buffered, recs...)
+		return nil
+	}
+	s.Log.Warn("Paused logs buffer overflowed, resuming logs indexing", "buffered", len(s.logsPause.buffered), "limit", s.cfg.MaxPausedLogs)
+	recs = append(s.logsPause.buffered, recs...)
+	s.logsPause.paused = false
+	s.logsPause.buffered = nil
+	s.logsPause.overflow = true
+	return recs
+}
@@ -0,0 +1,89 @@
+package evmstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStorePauseLogIndexing(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := LiteStoreConfig()
+	cfg.MaxPausedLogs = 10
+	store := NewStore(memorydb.New(), cfg)
+
+	addr := common.Address{1}
+	pattern := [][]common.Hash{{addr.Hash()}}
+	// data is decoded as an empty slice
+	withData := func(logs []*types.Log) []*types.Log {
+		for _, l := range logs {
+			l.Data = []byte{}
+		}
+		return logs
+	}
+	logs := withData(fakeLogs(addr, 4, 2))
+
+	store.PauseLogIndexing()
+	for _, l := range logs {
+		store.IndexLogs(l)
+	}
+	got, err := store.FindLogsInBlocks(ctx, 1, 4, pattern)
+	require.NoError(err)
+	require.Empty(got)
+
+	require.NoError(store.ResumeLogIndexing())
+	got, err = store.FindLogsInBlocks(ctx, 1, 4, pattern)
+	require.NoError(err)
+	require.ElementsMatch(logs, got)
+
+	// overflow resumes the indexing, logs aren't dropped
+	logs = withData(fakeLogs(addr, 8, 2))
+	store.PauseLogIndexing()
+	store.IndexLogs(logs[:8]...)
+	store.IndexLogs(logs[8:12]...)
+	got, err = store.FindLogsInBlocks(ctx, 1, 8, pattern)
+	require.NoError(err)
+	require.ElementsMatch(logs[:12], got)
+	store.IndexLogs(logs[12:]...)
+	got, err = store.FindLogsInBlocks(ctx, 1, 8, pattern)
+	require.NoError(err)
+	require.ElementsMatch(logs, got)
+	require.ErrorIs(store.ResumeLogIndexing(), ErrPausedLogsOverflow)
+	require.NoError(store.ResumeLogIndexing())
+
+	// flushed logs are indexed, the logs indexing stays paused
+	flushed := withData(fakeLogs(addr, 4, 1))
+	for _, l := range flushed {
+		l.BlockNumber += 10
+	}
+	store.PauseLogIndexing()
+	store.IndexLogs(flushed[:2]...)
+	store.FlushPausedLogs()
+	store.IndexLogs(flushed[2:]...)
+	got, err = store.FindLogsInBlocks(ctx, 11, 14, pattern)
+	require.NoError(err)
+	require.ElementsMatch(flushed[:2], got)
+	require.NoError(store.ResumeLogIndexing())
+	got, err = store.FindLogsInBlocks(ctx, 11, 14, pattern)
+	require.NoError(err)
+	require.ElementsMatch(flushed, got)
+
+	// buffered logs are flushed on close
+	openDB := reopenableDB()
+	store = NewStore(openDB(), cfg)
+	store.PauseLogIndexing()
+	store.IndexLogs(logs[:2]...)
+	require.NoError(store.Close())
+	got, err = NewStore(openDB(), cfg).FindLogsInBlocks(ctx, 1, 8, pattern)
+	require.NoError(err)
+	require.ElementsMatch(logs[:2], got)
+}
@@ -0,0 +1,274 @@
+package evmstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/leveldb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func fakeLogs(addr common.Address, blocks, perBlock int) []*types.Log {
+	logs := make([]*types.Log, 0, blocks*perBlock)
+	for b := 1; b <= blocks; b++ {
+		for i := 0; i < perBlock; i++ {
+			logs = append(logs, &types.Log{
+				Address:     addr,
+				Topics:      []common.Hash{hash.FakeHash(int64(i))},
+				BlockNumber: uint64(b),
+				BlockHash:   hash.FakeHash(int64(b)),
+				TxHash:      hash.FakeHash(int64(b*1000 + i)),
+				Index:       uint(i),
+			})
+		}
+	}
+	return logs
+}
+
+func TestStoreFindLogsInBlocksLimit(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	store.cfg.MaxLogsPerQuery = 10
+	addr := common.Address{1}
+	store.IndexLogs(fakeLogs(addr, 5, 3)...)
+	pattern := [][]common.Hash{{addr.Hash()}}
+
+	got, err := store.FindLogsInBlocks(context.Background(), 1, 3, pattern)
+	require.NoError(err)
+	require.Len(got, 9)
+
+	_, err = store.FindLogsInBlocks(context.Background(), 1, 5, pattern)
+	require.True(errors.Is(err, ErrTooManyLogs))
+
+	got, err = store.FindLogsInBlocksLimited(context.Background(), 1, 5, pattern, 0)
+	require.NoError(err)
+	require.Len(got, 15)
+}
+
+func TestStoreCountLogsLimit(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	addr := common.Address{1}
+	for _, blooms := range []bool{false, true} {
+		store := cachedStore()
+		store.cfg.EnableBlockBlooms = blooms
+		store.cfg.MaxLogsPerQuery = 10
+		store.IndexLogs(fakeLogs(addr, 5, 3)...)
+
+		count, err := store.CountLogs(context.Background(), []common.Address{addr}, 1, 3, nil)
+		require.NoError(err)
+		require.Equal(uint64(9), count, "blooms %v", blooms)
+
+		_, err = store.CountLogs(context.Background(), []common.Address{addr}, 1, 5, nil)
+		require.True(errors.Is(err, ErrTooManyLogs), "blooms %v", blooms)
+
+		store.cfg.MaxLogsPerQuery = 0
+		count, err = store.CountLogs(context.Background(), []common.Address{addr}, 1, 5, nil)
+		require.NoError(err)
+		require.Equal(uint64(15), count, "blooms %v", blooms)
+	}
+}
+
+// nextsCounter is a DB wrapper which counts iterations over the DB records with the prefix.
+type nextsCounter struct {
+	kvdb.Store
+	prefix []byte
+	nexts  *int
+}
+
+type countingIterator struct {
+	kvdb.Iterator
+	nexts *int
+}
+
+func (db nextsCounter) NewIterator(prefix []byte, start []byte) kvdb.Iterator {
+	it := db.Store.NewIterator(prefix, start)
+	if !bytes.HasPrefix(prefix, db.prefix) {
+		return it
+	}
+	return &countingIterator{it, db.nexts}
+}
+
+func (it *countingIterator) Next() bool {
+	*it.nexts++
+	return it.Iterator.Next()
+}
+
+func TestStoreCountLogsLimitByBlooms(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	var nexts int
+	cfg := LiteStoreConfig()
+	cfg.EnableBlockBlooms = true
+	cfg.MaxLogsPerQuery = 4
+	store := NewStore(nextsCounter{memorydb.New(), []byte(cfg.Tables.Logs), &nexts}, cfg)
+
+	addr := common.Address{1}
+	var logs []*types.Log
+	for _, l := range fakeLogs(addr, 200, 2) {
+		// the block 3 is skipped by the blooms, so blocks 1-2 and 4-200 are counted as separate ranges
+		if l.BlockNumber == 3 {
+			l.Address = common.Address{2}
+		}
+		// the first range matches the limit exactly
+		if l.BlockNumber > 3 && l.Index > 0 {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	store.IndexLogs(logs...)
+
+	count, err := store.CountLogs(context.Background(), []common.Address{addr}, 1, 2, nil)
+	require.NoError(err)
+	require.Equal(uint64(4), count)
+
+	nexts = 0
+	_, err = store.CountLogs(context.Background(), []common.Address{addr}, 1, 200, nil)
+	require.True(errors.Is(err, ErrTooManyLogs))
+	// the next range is counted only until the limit is exceeded
+	require.Less(nexts, 20)
+}
+
+func TestStoreGetLogsByAddresses(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	addr1, addr2, addr3 := common.Address{1}, common.Address{2}, common.Address{3}
+	topic1, topic2 := hash.FakeHash(1), hash.FakeHash(2)
+	var logs []*types.Log
+	for b := uint64(1); b <= 3; b++ {
+		for i, addr := range []common.Address{addr3, addr2, addr1} {
+			logs = append(logs, &types.Log{
+				Address:     addr,
+				Topics:      []common.Hash{topic1, topic2},
+				BlockNumber: b,
+				BlockHash:   hash.FakeHash(int64(b)),
+				TxHash:      hash.FakeHash(int64(b*10) + int64(i)),
+				Index:       uint(i),
+			})
+		}
+	}
+	// indexed in reverse order
+	for i := len(logs) - 1; i >= 0; i-- {
+		store.IndexLogs(logs[i])
+	}
+
+	check := func(got []*types.Log, expect ...*types.Log) {
+		require.Len(got, len(expect))
+		for i := range expect {
+			require.Equal(expect[i].BlockNumber, got[i].BlockNumber)
+			require.Equal(expect[i].Index, got[i].Index)
+			require.Equal(expect[i].Address, got[i].Address)
+		}
+	}
+
+	// overlapping addresses sets
+	got, err := store.GetLogsByAddresses(context.Background(), []common.Address{addr1, addr2, addr1}, 2, 3, nil)
+	require.NoError(err)
+	check(got, logs[4], logs[5], logs[7], logs[8])
+
+	got, err = store.GetLogsByAddresses(context.Background(), []common.Address{addr2, addr3}, 1, 3, [][]common.Hash{{topic1}, {topic2}})
+	require.NoError(err)
+	check(got, logs[0], logs[1], logs[3], logs[4], logs[6], logs[7])
+
+	got, err = store.GetLogsByAddresses(context.Background(), []common.Address{addr1}, 1, 3, [][]common.Hash{{topic2}})
+	require.NoError(err)
+	check(got)
+
+	// any address
+	got, err = store.GetLogsByAddresses(context.Background(), nil, 3, 3, [][]common.Hash{{topic1, topic2}})
+	require.NoError(err)
+	check(got, logs[6], logs[7], logs[8])
+}
+
+func TestStoreCompactLogsIndex(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "logs_compaction_test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	db, err := leveldb.New(dir, 16, 0, nil, nil)
+	require.NoError(err)
+	defer db.Close()
+
+	store := NewStore(db, LiteStoreConfig())
+	addr := common.Address{1}
+	store.IndexLogs(fakeLogs(addr, 100, 10)...)
+
+	require.NoError(store.CompactLogsIndex())
+
+	logs, err := store.FindLogsInBlocks(context.Background(), 1, 100, [][]common.Hash{{addr.Hash()}})
+	require.NoError(err)
+	require.Len(logs, 1000)
+}
+
+func TestStoreCountLogs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	addr1, addr2, addr3 := common.Address{1}, common.Address{2}, common.Address{3}
+	topic1, topic2, topic3 := hash.FakeHash(1), hash.FakeHash(2), hash.FakeHash(3)
+	var logs []*types.Log
+	for b := uint64(1); b <= 5; b++ {
+		for i, addr := range []common.Address{addr3, addr2, addr1} {
+			topics := []common.Hash{topic1, topic2}
+			if int(b)%(i+1) == 0 {
+				topics = []common.Hash{topic3}
+			}
+			logs = append(logs, &types.Log{
+				Address:     addr,
+				Topics:      topics,
+				BlockNumber: b,
+				BlockHash:   hash.FakeHash(int64(b)),
+				TxHash:      hash.FakeHash(int64(b*10) + int64(i)),
+				Index:       uint(i),
+			})
+		}
+	}
+
+	filters := []struct {
+		addrs    []common.Address
+		from, to idx.Block
+		topics   [][]common.Hash
+	}{
+		{[]common.Address{addr1}, 1, 5, nil},
+		{[]common.Address{addr1, addr2, addr1}, 2, 4, nil},
+		{[]common.Address{addr2, addr3}, 1, 5, [][]common.Hash{{topic1}, {topic2}}},
+		{nil, 1, 5, [][]common.Hash{{topic3}}},
+		{nil, 1, 5, [][]common.Hash{{topic1, topic3, topic1}}},
+		{nil, 3, 3, [][]common.Hash{{topic2}}},
+		{[]common.Address{addr1}, 4, 2, nil},
+	}
+	for _, blooms := range []bool{false, true} {
+		store := cachedStore()
+		store.cfg.EnableBlockBlooms = blooms
+		store.IndexLogs(logs...)
+		for i, f := range filters {
+			fetched, err := store.GetLogsByAddresses(context.Background(), f.addrs, f.from, f.to, f.topics)
+			require.NoError(err)
+			count, err := store.CountLogs(context.Background(), f.addrs, f.from, f.to, f.topics)
+			require.NoError(err)
+			require.Equal(uint64(len(fetched)), count, "filter %d, blooms %v", i, blooms)
+		}
+		_, err := store.CountLogs(context.Background(), nil, 1, 5, nil)
+		require.Error(err)
+	}
+}
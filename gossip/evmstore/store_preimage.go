@@ -0,0 +1,21 @@
+package evmstore
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// GetPreimage returns the preimage of a hashed trie key (an account address or a storage slot key).
+// The preimage is unknown if preimages recording is disabled.
+func (s *Store) GetPreimage(h common.Hash) ([]byte, bool) {
+	if !s.cfg.EnablePreimageRecording {
+		return nil, false
+	}
+	// the trie DB exposes the preimages store only through the secure trie
+	tr, err := trie.NewSecure(common.Hash{}, s.table.EvmState.TrieDB())
+	if err != nil {
+		return nil, false
+	}
+	preimage := tr.GetKey(h.Bytes())
+	return preimage, preimage != nil
+}
@@ -0,0 +1,62 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetPreimage(t *testing.T) {
+	logger.SetTestMode(t)
+
+	addr := common.BigToAddress(big.NewInt(1))
+	key := common.BigToHash(big.NewInt(2))
+	writeState := func(store *Store) {
+		statedb, err := store.StateDB(hash.Hash{})
+		require.NoError(t, err)
+		statedb.AddBalance(addr, big.NewInt(1))
+		statedb.SetState(addr, key, common.BigToHash(big.NewInt(3)))
+		root, err := statedb.Commit(true)
+		require.NoError(t, err)
+		require.NoError(t, store.Commit(hash.Hash(root)))
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		require := require.New(t)
+
+		cfg := LiteStoreConfig()
+		cfg.EnablePreimageRecording = true
+		store := NewStore(memorydb.New(), cfg)
+		writeState(store)
+
+		preimage, ok := store.GetPreimage(crypto.Keccak256Hash(addr.Bytes()))
+		require.True(ok)
+		require.Equal(addr.Bytes(), preimage)
+
+		preimage, ok = store.GetPreimage(crypto.Keccak256Hash(key.Bytes()))
+		require.True(ok)
+		require.Equal(key.Bytes(), preimage)
+
+		_, ok = store.GetPreimage(common.HexToHash("0x1234"))
+		require.False(ok)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require := require.New(t)
+
+		cfg := LiteStoreConfig()
+		cfg.EnablePreimageRecording = false
+		store := NewStore(memorydb.New(), cfg)
+		writeState(store)
+
+		_, ok := store.GetPreimage(crypto.Keccak256Hash(addr.Bytes()))
+		require.False(ok)
+	})
+}
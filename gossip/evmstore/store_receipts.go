@@ -5,6 +5,10 @@ package evmstore
 */
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
+
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -20,7 +24,7 @@ func (s *Store) SetReceipts(n idx.Block, receipts types.Receipts) {
 	size := s.SetRawReceipts(n, receiptsStorage)
 
 	// Add to LRU cache.
-	s.cache.Receipts.Add(n, receipts, uint(size))
+	addToCache(s.cache.Receipts, &s.cacheEvictions.Receipts, n, receipts, uint(size))
 }
 
 // SetRawReceipts stores raw transaction receipts.
@@ -49,12 +53,26 @@ func (s *Store) GetReceipts(n idx.Block) types.Receipts {
 		}
 	}
 
+	receipts, size := s.readReceipts(n)
+	if receipts == nil {
+		return nil
+	}
+
+	// Add to LRU cache.
+	addToCache(s.cache.Receipts, &s.cacheEvictions.Receipts, n, receipts, uint(size))
+
+	return receipts
+}
+
+// readReceipts reads and decodes stored transaction receipts bypassing the LRU cache.
+// Returns nil if receipts aren't found, and the size of the encoded receipts.
+func (s *Store) readReceipts(n idx.Block) (types.Receipts, int) {
 	buf, err := s.table.Receipts.Get(n.Bytes())
 	if err != nil {
 		s.Log.Crit("Failed to get key-value", "err", err)
 	}
 	if buf == nil {
-		return nil
+		return nil, 0
 	}
 
 	var receiptsStorage *[]*types.ReceiptForStorage
@@ -72,9 +90,127 @@ func (s *Store) GetReceipts(n idx.Block) types.Receipts {
 		}
 		receipts[i].GasUsed = receipts[i].CumulativeGasUsed - prev
 	}
+	return receipts, len(buf)
+}
 
-	// Add to LRU cache.
-	s.cache.Receipts.Add(n, receipts, uint(len(buf)))
+// getReceiptsBatch returns stored transaction receipts of the blocks, in the same order.
+// Receipts which aren't in the LRU cache are decoded in parallel by the receipts decoders of the store,
+// and then added to the cache in the order of the blocks, as if they were read by GetReceipts one by one.
+func (s *Store) getReceiptsBatch(ctx context.Context, blocks []idx.Block) ([]types.Receipts, error) {
+	res := make([]types.Receipts, len(blocks))
+	sizes := make([]int, len(blocks))
+	cached := make([]bool, len(blocks))
+	misses := make([]int, 0, len(blocks))
+	for i, n := range blocks {
+		if s.cache.Receipts != nil {
+			if c, ok := s.cache.Receipts.Peek(n); ok {
+				res[i], cached[i] = c.(types.Receipts), true
+				continue
+			}
+		}
+		misses = append(misses, i)
+	}
 
-	return receipts
+	err := s.decodeReceipts(ctx, len(misses), func(j int) {
+		i := misses[j]
+		res[i], sizes[i] = s.readReceipts(blocks[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache.Receipts != nil {
+		for i, n := range blocks {
+			if cached[i] {
+				// refresh the recency of the entry
+				s.cache.Receipts.Get(n)
+			} else if res[i] != nil {
+				addToCache(s.cache.Receipts, &s.cacheEvictions.Receipts, n, res[i], uint(sizes[i]))
+			}
+		}
+	}
+	return res, nil
+}
+
+// decodeReceipts calls decode for every task in [0, num). The calling goroutine decodes the tasks, and the receipts
+// decoders of the store help it if they aren't busy with other calls, so the number of the decoding goroutines
+// is bounded by StoreConfig.ReceiptsDecodeWorkers for all the calls together. The decoders exit before the call returns.
+func (s *Store) decodeReceipts(ctx context.Context, num int, decode func(task int)) error {
+	var next int64 = -1
+	var cancelled uint32
+	work := func() {
+		for {
+			if ctx.Err() != nil {
+				atomic.StoreUint32(&cancelled, 1)
+				return
+			}
+			task := int(atomic.AddInt64(&next, 1))
+			if task >= num {
+				return
+			}
+			decode(task)
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	// start the helpers only if there's more than a single task, and only while the decoders are free
+spawn:
+	for helpers := 0; s.receiptsDecoders != nil && helpers < num-1; helpers++ {
+		select {
+		case s.receiptsDecoders <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-s.receiptsDecoders }()
+				work()
+			}()
+		default:
+			break spawn
+		}
+	}
+	work()
+	wg.Wait()
+
+	if atomic.LoadUint32(&cancelled) != 0 {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// WarmReceiptsCache loads receipts of the lastNBlocks blocks up to the last one into the LRU cache.
+// The most recent blocks are loaded last, so they are the last to be evicted.
+func (s *Store) WarmReceiptsCache(ctx context.Context, last idx.Block, lastNBlocks int) error {
+	if s.cache.Receipts == nil || lastNBlocks <= 0 {
+		return nil
+	}
+	if lastNBlocks > s.cfg.Cache.ReceiptsBlocks {
+		lastNBlocks = s.cfg.Cache.ReceiptsBlocks
+	}
+	first := idx.Block(0)
+	if idx.Block(lastNBlocks) <= last {
+		first = last - idx.Block(lastNBlocks) + 1
+	}
+	blocks := make([]idx.Block, 0, last-first+1)
+	for n := first; n <= last; n++ {
+		blocks = append(blocks, n)
+	}
+	// receipts are added to the cache on reading
+	_, err := s.getReceiptsBatch(ctx, blocks)
+	return err
+}
+
+// GetReceiptsRange returns stored transaction receipts of blocks [from, to], ordered by block number.
+// Receipts are decoded in parallel by the receipts decoders of the store, see StoreConfig.ReceiptsDecodeWorkers.
+func (s *Store) GetReceiptsRange(ctx context.Context, from, to idx.Block) ([]types.Receipts, error) {
+	if from > to {
+		return nil, nil
+	}
+	blocks := make([]idx.Block, 0, to-from+1)
+	for n := from; ; n++ {
+		blocks = append(blocks, n)
+		if n == to {
+			break
+		}
+	}
+	return s.getReceiptsBatch(ctx, blocks)
 }
@@ -1,12 +1,17 @@
 package evmstore
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/Fantom-foundation/go-opera/logger"
 )
@@ -103,3 +108,187 @@ func fakeReceipts() (idx.Block, types.Receipts) {
 			},
 		}
 }
+
+func TestStoreReceiptsBloomRecomputed(t *testing.T) {
+	logger.SetTestMode(t)
+
+	// Note: storage encoding of receipts doesn't contain blooms, they are recomputed on reading
+	block := idx.Block(1)
+	expect := types.Receipts{
+		&types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 30000,
+			Logs: []*types.Log{
+				{Address: common.Address{1}, Topics: []common.Hash{{2}, {3}}},
+				{Address: common.Address{4}},
+			},
+		},
+	}
+	expect[0].Bloom = types.CreateBloom(expect)
+
+	store := nonCachedStore()
+	store.SetReceipts(block, expect)
+
+	got := store.GetReceipts(block)
+	assert.Equal(t, expect[0].Bloom, got[0].Bloom)
+}
+
+func fakeReceiptsDB(blocks idx.Block) kvdb.Store {
+	db := memorydb.New()
+	store := NewStore(db, StoreConfig{})
+	_, receipts := fakeReceipts()
+	for n := idx.Block(1); n <= blocks; n++ {
+		store.SetReceipts(n, receipts)
+	}
+	return db
+}
+
+func TestStoreWarmReceiptsCache(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := fakeReceiptsDB(20)
+
+	store := NewStore(db, LiteStoreConfig())
+	require.NoError(store.WarmReceiptsCache(context.Background(), 20, 5))
+	for n := idx.Block(1); n <= 20; n++ {
+		require.Equal(n > 15, store.cache.Receipts.Contains(n), n)
+	}
+
+	store = NewStore(db, LiteStoreConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(context.Canceled, store.WarmReceiptsCache(ctx, 20, 5))
+	require.Equal(0, store.cache.Receipts.Len())
+}
+
+func BenchmarkStoreReceiptsWarmup(b *testing.B) {
+	logger.SetTestMode(b)
+
+	const blocks = 100
+	db := fakeReceiptsDB(blocks)
+
+	b.Run("cold", func(b *testing.B) {
+		benchStoreReceiptsMisses(b, db, 0)
+	})
+	b.Run("warm", func(b *testing.B) {
+		benchStoreReceiptsMisses(b, db, blocks)
+	})
+}
+
+func benchStoreReceiptsMisses(b *testing.B, db kvdb.Store, warmup int) {
+	const last = idx.Block(100)
+	misses := 0
+	for i := 0; i < b.N; i++ {
+		store := NewStore(db, LiteStoreConfig())
+		if err := store.WarmReceiptsCache(context.Background(), last, warmup); err != nil {
+			b.Fatal(err)
+		}
+		for n := idx.Block(1); n <= last; n++ {
+			if !store.cache.Receipts.Contains(n) {
+				misses++
+			}
+			store.GetReceipts(n)
+		}
+	}
+	b.ReportMetric(float64(misses)/float64(b.N*int(last)), "misses/read")
+}
+
+func TestStoreGetReceiptsRange(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	store := NewStore(db, StoreConfig{})
+	for n := idx.Block(1); n <= 50; n++ {
+		store.SetReceipts(n, types.Receipts{
+			&types.Receipt{CumulativeGasUsed: uint64(n), Logs: []*types.Log{}},
+		})
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		cfg := StoreConfig{ReceiptsDecodeWorkers: workers}
+		store := NewStore(db, cfg)
+		got, err := store.GetReceiptsRange(context.Background(), 5, 45)
+		require.NoError(err)
+		require.Len(got, 41)
+		for i, receipts := range got {
+			require.Len(receipts, 1)
+			require.Equal(uint64(5+i), receipts[0].CumulativeGasUsed, workers)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewStore(db, StoreConfig{ReceiptsDecodeWorkers: 4}).GetReceiptsRange(ctx, 1, 50)
+	require.Equal(context.Canceled, err)
+}
+
+func TestStoreGetReceiptsRangeBusyDecoders(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	store := NewStore(db, StoreConfig{ReceiptsDecodeWorkers: 4})
+	for n := idx.Block(1); n <= 50; n++ {
+		store.SetReceipts(n, types.Receipts{
+			&types.Receipt{CumulativeGasUsed: uint64(n), Logs: []*types.Log{}},
+		})
+	}
+	require.Equal(3, cap(store.receiptsDecoders))
+
+	// all the decoders are busy with other reads, so the receipts are decoded by the calling goroutine only
+	for i := 0; i < cap(store.receiptsDecoders); i++ {
+		store.receiptsDecoders <- struct{}{}
+	}
+	got, err := store.GetReceiptsRange(context.Background(), 1, 50)
+	require.NoError(err)
+	require.Len(got, 50)
+	for i, receipts := range got {
+		require.Equal(uint64(1+i), receipts[0].CumulativeGasUsed)
+	}
+	require.Equal(cap(store.receiptsDecoders), len(store.receiptsDecoders))
+
+	// the decoders are released once the read returns
+	for i := 0; i < cap(store.receiptsDecoders); i++ {
+		<-store.receiptsDecoders
+	}
+	_, err = store.GetReceiptsRange(context.Background(), 1, 50)
+	require.NoError(err)
+	require.Equal(0, len(store.receiptsDecoders))
+}
+
+func BenchmarkStoreGetReceiptsRange(b *testing.B) {
+	logger.SetTestMode(b)
+
+	const blocks = 200
+	db := memorydb.New()
+	store := NewStore(db, StoreConfig{})
+	for n := idx.Block(1); n <= blocks; n++ {
+		receipts := make(types.Receipts, 20)
+		for i := range receipts {
+			logs := make([]*types.Log, 10)
+			for j := range logs {
+				logs[j] = &types.Log{
+					Address: common.Address{byte(i)},
+					Topics:  []common.Hash{{byte(j)}, {byte(n)}},
+					Data:    make([]byte, 64),
+				}
+			}
+			receipts[i] = &types.Receipt{CumulativeGasUsed: uint64(i), Logs: logs}
+		}
+		store.SetReceipts(n, receipts)
+	}
+
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			store := NewStore(db, StoreConfig{ReceiptsDecodeWorkers: workers})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.GetReceiptsRange(context.Background(), 1, blocks); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SnapshotRoots returns the state roots of the EVM snapshot layers, from the head layer down to the disk layer.
+// The disk layer root is returned even if there's no layer for the head. Returns nil if the snapshot is disabled.
+func (s *Store) SnapshotRoots(head hash.Hash) []hash.Hash {
+	if s.table.Snaps == nil {
+		return nil
+	}
+	var roots []hash.Hash
+	for _, snap := range s.table.Snaps.Snapshots(common.Hash(head), -1, false) {
+		roots = append(roots, hash.Hash(snap.Root()))
+	}
+	if disk := s.table.Snaps.DiskRoot(); len(roots) == 0 && disk != (common.Hash{}) {
+		roots = append(roots, hash.Hash(disk))
+	}
+	return roots
+}
@@ -0,0 +1,47 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreSnapshotRoots(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := LiteStoreConfig()
+	cfg.Cache.EvmSnap = 1024 * 1024
+	cfg.SnapLayers = 8
+	store := NewStore(memorydb.New(), cfg)
+
+	commit := func(from hash.Hash, i int64) hash.Hash {
+		statedb, err := store.StateDB(from)
+		require.NoError(err)
+		statedb.AddBalance(common.BigToAddress(big.NewInt(i)), big.NewInt(i))
+		root, err := statedb.Commit(true)
+		require.NoError(err)
+		require.NoError(store.Commit(hash.Hash(root)))
+		return hash.Hash(root)
+	}
+
+	root0 := commit(hash.Hash{}, 1)
+	require.Nil(store.SnapshotRoots(root0))
+
+	require.NoError(store.InitEvmSnapshot(root0))
+	require.Equal([]hash.Hash{root0}, store.SnapshotRoots(root0))
+
+	root1 := commit(root0, 2)
+	root2 := commit(root1, 3)
+	require.Equal([]hash.Hash{root2, root1, root0}, store.SnapshotRoots(root2))
+	require.Equal([]hash.Hash{root1, root0}, store.SnapshotRoots(root1))
+
+	// unknown head
+	require.Equal([]hash.Hash{root0}, store.SnapshotRoots(hash.Hash{1}))
+}
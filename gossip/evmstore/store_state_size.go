@@ -0,0 +1,104 @@
+package evmstore
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// StateSizeEstimate returns the number of accounts and storage slots at the state root.
+// Note: it iterates the whole state, which is expensive for a large state.
+// The snapshot is iterated if it's available, the tries are iterated otherwise.
+func (s *Store) StateSizeEstimate(root hash.Hash) (accounts, slots uint64, err error) {
+	return s.StateSizeEstimateSampled(root, 1)
+}
+
+// StateSizeEstimateSampled is the same as StateSizeEstimate, but storage is iterated only for every sampleEvery-th account.
+// The number of slots is extrapolated from the sampled accounts. The number of accounts is always exact.
+func (s *Store) StateSizeEstimateSampled(root hash.Hash, sampleEvery uint64) (accounts, slots uint64, err error) {
+	if sampleEvery == 0 {
+		sampleEvery = 1
+	}
+	accounts, slots, err = s.snapshotStateSize(common.Hash(root), sampleEvery)
+	if err != nil {
+		accounts, slots, err = s.trieStateSize(common.Hash(root), sampleEvery)
+	}
+	return accounts, slots * sampleEvery, err
+}
+
+func (s *Store) snapshotStateSize(root common.Hash, sampleEvery uint64) (accounts, slots uint64, err error) {
+	if s.table.Snaps == nil {
+		return 0, 0, snapshot.ErrNotConstructed
+	}
+	accIt, err := s.table.Snaps.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer accIt.Release()
+
+	for accIt.Next() {
+		accounts++
+		if (accounts-1)%sampleEvery != 0 {
+			continue
+		}
+		var acc snapshot.Account
+		if err := rlp.DecodeBytes(accIt.Account(), &acc); err != nil {
+			return 0, 0, err
+		}
+		if len(acc.Root) == 0 || common.BytesToHash(acc.Root) == types.EmptyRootHash {
+			continue
+		}
+		stIt, err := s.table.Snaps.StorageIterator(root, accIt.Hash(), common.Hash{})
+		if err != nil {
+			return 0, 0, err
+		}
+		for stIt.Next() {
+			if len(stIt.Slot()) != 0 {
+				slots++
+			}
+		}
+		err = stIt.Error()
+		stIt.Release()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return accounts, slots, accIt.Error()
+}
+
+func (s *Store) trieStateSize(root common.Hash, sampleEvery uint64) (accounts, slots uint64, err error) {
+	tr, err := s.table.EvmState.OpenTrie(root)
+	if err != nil {
+		return 0, 0, err
+	}
+	accIt := trie.NewIterator(tr.NodeIterator(nil))
+	for accIt.Next() {
+		accounts++
+		if (accounts-1)%sampleEvery != 0 {
+			continue
+		}
+		var acc state.Account
+		if err := rlp.DecodeBytes(accIt.Value, &acc); err != nil {
+			return 0, 0, err
+		}
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		st, err := s.table.EvmState.OpenStorageTrie(common.BytesToHash(accIt.Key), acc.Root)
+		if err != nil {
+			return 0, 0, err
+		}
+		stIt := trie.NewIterator(st.NodeIterator(nil))
+		for stIt.Next() {
+			slots++
+		}
+		if stIt.Err != nil {
+			return 0, 0, stIt.Err
+		}
+	}
+	return accounts, slots, accIt.Err
+}
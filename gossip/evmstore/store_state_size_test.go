@@ -0,0 +1,55 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStateSizeEstimate(t *testing.T) {
+	logger.SetTestMode(t)
+
+	for name, snap := range map[string]bool{"trie": false, "snapshot": true} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := LiteStoreConfig()
+			cfg.Cache.EvmSnap = 1024 * 1024
+			store := NewStore(memorydb.New(), cfg)
+			statedb, err := store.StateDB(hash.Hash{})
+			require.NoError(err)
+			// 10 accounts, 4 of them with 5 slots each
+			for i := int64(1); i <= 10; i++ {
+				addr := common.BigToAddress(big.NewInt(i))
+				statedb.AddBalance(addr, big.NewInt(i))
+				if i%3 == 0 || i == 10 {
+					for j := int64(1); j <= 5; j++ {
+						statedb.SetState(addr, common.BigToHash(big.NewInt(j)), common.BigToHash(big.NewInt(j)))
+					}
+				}
+			}
+			root, err := statedb.Commit(true)
+			require.NoError(err)
+			require.NoError(store.Commit(hash.Hash(root)))
+			if snap {
+				require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+			}
+
+			accounts, slots, err := store.StateSizeEstimate(hash.Hash(root))
+			require.NoError(err)
+			require.Equal(uint64(10), accounts)
+			require.Equal(uint64(20), slots)
+
+			accounts, slots, err = store.StateSizeEstimateSampled(hash.Hash(root), 2)
+			require.NoError(err)
+			require.Equal(uint64(10), accounts)
+			require.Equal(uint64(0), slots%2)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package evmstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/core/state"
+)
+
+// StateDBCtx returns a state database, same as StateDB, but the number of the instances acquired concurrently
+// is limited by StoreConfig.MaxStateDBs. It waits for a slot until ctx is done, ctx error is returned in such case.
+// The returned release func must be called once the state database isn't used anymore, it's safe to call it multiple times.
+// Note: StateDB isn't limited, as its instances aren't released, e.g. it's used by the blocks processing.
+func (s *Store) StateDBCtx(ctx context.Context, from hash.Hash) (*state.StateDB, func(), error) {
+	release, err := s.acquireStateDB(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, err := s.StateDB(from)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return statedb, release, nil
+}
+
+func (s *Store) acquireStateDB(ctx context.Context) (func(), error) {
+	if s.stateDBs == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.stateDBs <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-s.stateDBs
+		})
+	}, nil
+}
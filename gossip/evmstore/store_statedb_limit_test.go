@@ -0,0 +1,69 @@
+package evmstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStateDBCtx(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	const max = 3
+	cfg := LiteStoreConfig()
+	cfg.MaxStateDBs = max
+	store := NewStore(memorydb.New(), cfg)
+
+	releases := make([]func(), max)
+	for i := range releases {
+		statedb, release, err := store.StateDBCtx(ctx, hash.Hash{})
+		require.NoError(err)
+		require.NotNil(statedb)
+		releases[i] = release
+	}
+
+	// the Nth acquisition is blocked until ctx is done
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err := store.StateDBCtx(timeout, hash.Hash{})
+	require.ErrorIs(err, context.DeadlineExceeded)
+
+	// the Nth acquisition is blocked until a release
+	acquired := make(chan func())
+	go func() {
+		_, release, err := store.StateDBCtx(ctx, hash.Hash{})
+		if err != nil {
+			close(acquired)
+			return
+		}
+		acquired <- release
+	}()
+	select {
+	case <-acquired:
+		require.Fail("acquired over the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+	releases[0]()
+	// a repeated release doesn't free a slot
+	releases[0]()
+	release, ok := <-acquired
+	require.True(ok)
+	require.Len(store.stateDBs, max)
+	release()
+	require.Len(store.stateDBs, max-1)
+
+	// unlimited by default
+	store = NewStore(memorydb.New(), LiteStoreConfig())
+	for i := 0; i < 10*max; i++ {
+		_, _, err := store.StateDBCtx(ctx, hash.Hash{})
+		require.NoError(err)
+	}
+}
@@ -0,0 +1,102 @@
+package evmstore
+
+import (
+	"errors"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	// ErrAccountNotFound is returned if a storage range is requested for a nonexistent account
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrInvalidStorageRangeLimit is returned if a storage range is requested with a non-positive limit
+	ErrInvalidStorageRangeLimit = errors.New("storage range limit must be positive")
+)
+
+// StorageEntry is a storage slot of a contract. Slots are ordered by the hash of the slot key.
+type StorageEntry struct {
+	Hash  common.Hash `json:"hash"`
+	Value common.Hash `json:"value"`
+}
+
+// StorageRangeAt returns up to limit storage slots of addr at the state root, starting from the slot key hash start.
+// The returned cursor is the hash of the next slot to request, it's zero if there are no more slots.
+// The snapshot is used if it's available, the storage trie is iterated otherwise.
+func (s *Store) StorageRangeAt(root hash.Hash, addr common.Address, start common.Hash, limit int) ([]StorageEntry, common.Hash, error) {
+	if limit <= 0 {
+		return nil, common.Hash{}, ErrInvalidStorageRangeLimit
+	}
+	statedb, err := s.StateDB(root)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if !statedb.Exist(addr) {
+		return nil, common.Hash{}, ErrAccountNotFound
+	}
+
+	// fetch one extra slot to find out the next cursor
+	entries, err := s.snapshotStorageRange(root, addr, start, limit+1)
+	if err != nil {
+		entries = make([]StorageEntry, 0, limit+1)
+		it := trie.NewIterator(statedb.StorageTrie(addr).NodeIterator(start.Bytes()))
+		for len(entries) < limit+1 && it.Next() {
+			entry, err := decodeStorageEntry(common.BytesToHash(it.Key), it.Value)
+			if err != nil {
+				return nil, common.Hash{}, err
+			}
+			entries = append(entries, entry)
+		}
+		if it.Err != nil {
+			return nil, common.Hash{}, it.Err
+		}
+	}
+
+	var next common.Hash
+	if len(entries) > limit {
+		next = entries[limit].Hash
+		entries = entries[:limit]
+	}
+	return entries, next, nil
+}
+
+// snapshotStorageRange reads the storage slots from the snapshot.
+// An error is returned if the snapshot isn't available for the root (e.g. it's still being generated).
+func (s *Store) snapshotStorageRange(root hash.Hash, addr common.Address, start common.Hash, limit int) ([]StorageEntry, error) {
+	if s.table.Snaps == nil {
+		return nil, errors.New("snapshot is disabled")
+	}
+	it, err := s.table.Snaps.StorageIterator(common.Hash(root), crypto.Keccak256Hash(addr.Bytes()), start)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	entries := make([]StorageEntry, 0, limit)
+	for len(entries) < limit && it.Next() {
+		if len(it.Slot()) == 0 {
+			// deleted slot
+			continue
+		}
+		entry, err := decodeStorageEntry(it.Hash(), it.Slot())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, it.Error()
+}
+
+func decodeStorageEntry(h common.Hash, enc []byte) (StorageEntry, error) {
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return StorageEntry{}, err
+	}
+	return StorageEntry{
+		Hash:  h,
+		Value: common.BytesToHash(content),
+	}, nil
+}
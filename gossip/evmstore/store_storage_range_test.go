@@ -0,0 +1,82 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStorageRangeAt(t *testing.T) {
+	logger.SetTestMode(t)
+
+	const slots = 10
+	contract := common.HexToAddress("0xc0ffee")
+
+	prepare := func(t *testing.T, snap bool) (*Store, hash.Hash) {
+		cfg := LiteStoreConfig()
+		cfg.Cache.EvmSnap = 1024 * 1024
+		store := NewStore(memorydb.New(), cfg)
+		statedb, err := store.StateDB(hash.Hash{})
+		require.NoError(t, err)
+		statedb.AddBalance(contract, big.NewInt(1))
+		for i := int64(0); i < slots; i++ {
+			statedb.SetState(contract, common.BigToHash(big.NewInt(i)), common.BigToHash(big.NewInt(i+1)))
+		}
+		root, err := statedb.Commit(true)
+		require.NoError(t, err)
+		require.NoError(t, store.Commit(hash.Hash(root)))
+		if snap {
+			require.NoError(t, store.InitEvmSnapshot(hash.Hash(root)))
+		}
+		return store, hash.Hash(root)
+	}
+
+	for name, snap := range map[string]bool{"trie": false, "snapshot": true} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			store, root := prepare(t, snap)
+
+			var (
+				all    []StorageEntry
+				cursor common.Hash
+			)
+			for calls := 0; ; calls++ {
+				require.Less(calls, slots)
+				entries, next, err := store.StorageRangeAt(root, contract, cursor, 3)
+				require.NoError(err)
+				require.LessOrEqual(len(entries), 3)
+				all = append(all, entries...)
+				if next == (common.Hash{}) {
+					break
+				}
+				cursor = next
+			}
+
+			require.Len(all, slots)
+			values := map[common.Hash]bool{}
+			for i, e := range all {
+				if i > 0 {
+					require.Less(all[i-1].Hash.Big().Cmp(e.Hash.Big()), 0, "slots must be ordered without duplicates")
+				}
+				values[e.Value] = true
+			}
+			for i := int64(0); i < slots; i++ {
+				require.True(values[common.BigToHash(big.NewInt(i+1))])
+			}
+
+			_, _, err := store.StorageRangeAt(root, common.HexToAddress("0xdead"), common.Hash{}, 3)
+			require.ErrorIs(err, ErrAccountNotFound)
+
+			for _, limit := range []int{0, -1, -2} {
+				_, _, err = store.StorageRangeAt(root, contract, common.Hash{}, limit)
+				require.ErrorIs(err, ErrInvalidStorageRangeLimit, limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package evmstore
+
+// TableSizes returns the sizes of the store tables in bytes, by table name.
+// The size is an estimate: it's a total size of the table keys and values, which is calculated by iterating the table.
+// The actual on-disk size differs due to the DB compression and not compacted data.
+// It's expensive for large tables, so it's intended only for diagnostics.
+func (s *Store) TableSizes() map[string]uint64 {
+	t := s.cfg.Tables
+	tables := map[string]string{
+		"Receipts":        t.Receipts,
+		"TxPositions":     t.TxPositions,
+		"Txs":             t.Txs,
+		"EpochBlocks":     t.EpochBlocks,
+		"BlockEpochs":     t.BlockEpochs,
+		"BlockBlooms":     t.BlockBlooms,
+		"BlockValidators": t.BlockValidators,
+		"Evm":             t.Evm,
+		"EvmLogs":         t.Logs,
+	}
+	sizes := make(map[string]uint64, len(tables))
+	for name, prefix := range tables {
+		sizes[name] = s.prefixSize([]byte(prefix))
+	}
+	return sizes
+}
+
+func (s *Store) prefixSize(prefix []byte) uint64 {
+	it := s.mainDB.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var size uint64
+	for it.Next() {
+		size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		s.Log.Crit("Failed to iterate keys", "err", err)
+	}
+	return size
+}
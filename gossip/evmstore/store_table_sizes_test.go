@@ -0,0 +1,54 @@
+package evmstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreTableSizes(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	cfg := LiteStoreConfig()
+	cfg.EnableBlockBlooms = true
+	store := NewStore(db, cfg)
+
+	n, receipts := fakeReceipts()
+	store.SetReceipts(n, receipts)
+	tx := types.NewTransaction(1, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	store.SetTx(tx.Hash(), tx)
+	store.SetTxPosition(tx.Hash(), TxPosition{Block: n})
+	store.SetEpochSealBlock(1, n)
+	store.SetBlockValidator(n, 1)
+	store.IndexLogs(fakeLogs(common.Address{1}, 3, 2)...)
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	statedb.AddBalance(common.Address{1}, big.NewInt(1))
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(store.Commit(hash.Hash(root)))
+
+	sizes := store.TableSizes()
+	var total uint64
+	for name, size := range sizes {
+		require.NotZero(size, name)
+		total += size
+	}
+
+	var dbSize uint64
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		dbSize += uint64(len(it.Key()) + len(it.Value()))
+	}
+	it.Release()
+	require.Equal(dbSize, total)
+}
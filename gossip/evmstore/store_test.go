@@ -1,10 +1,20 @@
 package evmstore
 
 import (
+	"context"
+	"math/big"
+	"runtime"
+	"testing"
 	"time"
 
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
 )
 
 func cachedStore() *Store {
@@ -19,6 +29,15 @@ func nonCachedStore() *Store {
 	return NewStore(memorydb.New(), cfg)
 }
 
+// reopenableDB returns a func which opens the same memory DB, the DB data survive Close.
+func reopenableDB() func() kvdb.Store {
+	dbs := memorydb.NewProducer("")
+	return func() kvdb.Store {
+		db, _ := dbs.OpenDB("evm")
+		return db
+	}
+}
+
 func withDelay(db kvdb.DropableStore) kvdb.DropableStore {
 	mem, ok := db.(*memorydb.Database)
 	if ok {
@@ -28,3 +47,224 @@ func withDelay(db kvdb.DropableStore) kvdb.DropableStore {
 
 	return db
 }
+
+func TestStoreClose(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	goroutines := runtime.NumGoroutine()
+
+	cfg := LiteStoreConfig()
+	cfg.Cache.EvmSnap = 1024 * 1024
+	store := NewStore(memorydb.New(), cfg)
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	for i := int64(0); i < 1000; i++ {
+		statedb.AddBalance(common.BigToAddress(big.NewInt(i+1)), big.NewInt(i+1))
+	}
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(store.Commit(hash.Hash(root)))
+	require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+
+	require.NoError(store.Close())
+	// second Close is a no-op
+	require.NoError(store.Close())
+
+	// snapshot generation is stopped
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.LessOrEqual(runtime.NumGoroutine(), goroutines)
+}
+
+func TestStoreTrieDBStats(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	stats := store.TrieDBStats()
+	require.Zero(stats.DirtyNodes)
+	require.Zero(stats.DirtySize)
+
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	for i := int64(0); i < 100; i++ {
+		statedb.AddBalance(common.BigToAddress(big.NewInt(i+1)), big.NewInt(i+1))
+	}
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+
+	stats = store.TrieDBStats()
+	require.Greater(stats.DirtyNodes, 0)
+	require.Greater(float64(stats.DirtySize), 0.0)
+	require.GreaterOrEqual(float64(stats.PreimagesSize), 0.0)
+
+	// flushed nodes aren't dirty anymore
+	require.NoError(store.Commit(hash.Hash(root)))
+	stats = store.TrieDBStats()
+	require.Zero(stats.DirtyNodes)
+
+	// the clean cache counters are non-negative, and the hit rate is a ratio
+	require.GreaterOrEqual(stats.CleanHits, int64(0))
+	require.GreaterOrEqual(stats.CleanMisses, int64(0))
+	require.GreaterOrEqual(stats.CleanHitRate, 0.0)
+	require.LessOrEqual(stats.CleanHitRate, 1.0)
+	require.Zero(meterCount("trie/memcache/unknown"))
+}
+
+func TestStoreSnapLayers(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	for _, layers := range []int{0, 1} {
+		cfg := LiteStoreConfig()
+		cfg.Cache.EvmSnap = 1024 * 1024
+		cfg.SnapLayers = layers
+		store := NewStore(memorydb.New(), cfg)
+
+		statedb, err := store.StateDB(hash.Hash{})
+		require.NoError(err)
+		statedb.AddBalance(common.Address{1}, big.NewInt(1))
+		root, err := statedb.Commit(true)
+		require.NoError(err)
+		require.NoError(store.Commit(hash.Hash(root)))
+		require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+
+		var roots []common.Hash
+		for i := int64(2); i <= 3; i++ {
+			statedb, err = store.StateDB(hash.Hash(root))
+			require.NoError(err)
+			statedb.AddBalance(common.Address{byte(i)}, big.NewInt(i))
+			root, err = statedb.Commit(true)
+			require.NoError(err)
+			roots = append(roots, root)
+		}
+
+		head, prev := roots[1], roots[0]
+		require.NotNil(store.table.Snaps.Snapshot(head), layers)
+		// the root one block behind the head is served from a diff layer only if layers are retained
+		require.Equal(layers > 0, store.table.Snaps.Snapshot(prev) != nil, layers)
+		if layers > 0 {
+			statedb, err = store.StateDB(hash.Hash(prev))
+			require.NoError(err)
+			require.Equal(big.NewInt(2), statedb.GetBalance(common.Address{2}))
+		}
+	}
+}
+
+func TestStoreEpochSealBlocks(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	_, ok := store.GetEpochSealBlock(1)
+	require.False(ok)
+	_, ok = store.BlockEpoch(1)
+	require.False(ok)
+
+	// epoch 1 is sealed by block 10, epoch 2 by block 11, epoch 3 by block 25
+	seals := map[idx.Epoch]idx.Block{1: 10, 2: 11, 3: 25}
+	for epoch, n := range seals {
+		store.SetEpochSealBlock(epoch, n)
+	}
+
+	for epoch, n := range seals {
+		got, ok := store.GetEpochSealBlock(epoch)
+		require.True(ok)
+		require.Equal(uint64(n), got)
+	}
+	_, ok = store.GetEpochSealBlock(4)
+	require.False(ok)
+
+	for n, expect := range map[uint64]idx.Epoch{1: 1, 10: 1, 11: 2, 12: 3, 25: 3} {
+		epoch, ok := store.BlockEpoch(n)
+		require.True(ok, n)
+		require.Equal(expect, epoch, n)
+	}
+	_, ok = store.BlockEpoch(26)
+	require.False(ok)
+}
+
+func TestStoreTablesConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(DefaultStoreTablesConfig().Validate())
+	require.NoError(StoreTablesConfig{}.Validate())
+	require.NoError(StoreTablesConfig{Receipts: "rr"}.Validate())
+
+	require.Error(StoreTablesConfig{Receipts: "x"}.Validate())
+	require.Error(StoreTablesConfig{Receipts: "Mr"}.Validate())
+	require.Error(StoreTablesConfig{Logs: "Lx", Evm: "L"}.Validate())
+	require.NoError(DefaultStoreTablesConfig().Validate("e", "b"))
+	require.Error(StoreTablesConfig{Receipts: "e"}.Validate("e", "b"))
+	require.Error(StoreTablesConfig{Receipts: "bb"}.Validate("e", "b"))
+	require.Error(StoreTablesConfig{Receipts: "e"}.Validate("ex"))
+}
+
+func TestStoreCustomTables(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	db := memorydb.New()
+	cfg := LiteStoreConfig()
+	cfg.Tables = StoreTablesConfig{
+		Receipts:        "1r",
+		TxPositions:     "1x",
+		Txs:             "1X",
+		EpochBlocks:     "1E",
+		BlockEpochs:     "1p",
+		BlockBlooms:     "1f",
+		BlockValidators: "1a",
+		Evm:             "1M",
+		Logs:            "1L",
+	}
+	custom := NewStore(db, cfg)
+	def := NewStore(db, LiteStoreConfig())
+
+	_, receipts := fakeReceipts()
+	custom.SetReceipts(1, receipts)
+	custom.SetTxPosition(common.Hash{1}, TxPosition{Block: 1})
+	custom.SetEpochSealBlock(1, 1)
+	custom.SetBlockValidator(1, 1)
+	custom.IndexLogs(fakeLogs(common.Address{1}, 1, 1)...)
+	statedb, err := custom.StateDB(hash.Hash{})
+	require.NoError(err)
+	statedb.AddBalance(common.Address{1}, big.NewInt(1))
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(custom.Commit(hash.Hash(root)))
+
+	// the data is visible only to the store with the same tables
+	for _, s := range []*Store{custom, NewStore(db, cfg)} {
+		require.NotNil(s.GetReceipts(1))
+		require.NotNil(s.GetTxPosition(common.Hash{1}))
+		_, ok := s.GetEpochSealBlock(1)
+		require.True(ok)
+		_, ok = s.GetBlockValidator(1)
+		require.True(ok)
+		logs, err := s.FindLogsInBlocks(context.Background(), 1, 1, [][]common.Hash{{common.Address{1}.Hash()}})
+		require.NoError(err)
+		require.Len(logs, 1)
+		_, err = s.StateDB(hash.Hash(root))
+		require.NoError(err)
+	}
+	require.Nil(def.GetReceipts(1))
+	require.Nil(def.GetTxPosition(common.Hash{1}))
+	_, ok := def.GetEpochSealBlock(1)
+	require.False(ok)
+	_, ok = def.GetBlockValidator(1)
+	require.False(ok)
+	logs, err := def.FindLogsInBlocks(context.Background(), 1, 1, [][]common.Hash{{common.Address{1}.Hash()}})
+	require.NoError(err)
+	require.Len(logs, 0)
+	_, err = def.StateDB(hash.Hash(root))
+	require.Error(err)
+
+	// all the keys are within the custom tables
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		require.Equal(byte('1'), it.Key()[0], string(it.Key()))
+	}
+}
@@ -0,0 +1,34 @@
+package evmstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// prepareJournalDir expands the journal directory path, creates the directory if it's missing,
+// and checks that it's writable. Returns the expanded path.
+func prepareJournalDir(path string) (string, error) {
+	dir := os.ExpandEnv(path)
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	dir = filepath.Clean(dir)
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	f, err := ioutil.TempFile(dir, ".write-check")
+	if err != nil {
+		return "", fmt.Errorf("journal directory isn't writable: %w", err)
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return dir, nil
+}
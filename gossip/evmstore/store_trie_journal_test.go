@@ -0,0 +1,59 @@
+package evmstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreTrieCleanJournal(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "trie_journal_test")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	t.Run("valid", func(t *testing.T) {
+		os.Setenv("TRIE_JOURNAL_TEST_DIR", tmp)
+		defer os.Unsetenv("TRIE_JOURNAL_TEST_DIR")
+
+		// the config validation doesn't create the directory
+		cfg := LiteStoreConfig()
+		cfg.TrieCleanJournal = "$TRIE_JOURNAL_TEST_DIR/network-1/triecache"
+		require.NoError(cfg.Validate())
+		_, err := os.Stat(filepath.Join(tmp, "network-1"))
+		require.True(os.IsNotExist(err))
+
+		dir, err := prepareJournalDir(cfg.TrieCleanJournal)
+		require.NoError(err)
+		require.Equal(filepath.Join(tmp, "network-1", "triecache"), dir)
+		info, err := os.Stat(dir)
+		require.NoError(err)
+		require.True(info.IsDir())
+
+		// the journal is saved on close
+		cfg = LiteStoreConfig()
+		cfg.Cache.EvmDatabase = 1024 * 1024
+		cfg.TrieCleanJournal = dir
+		store := NewStore(memorydb.New(), cfg)
+		require.NoError(store.Close())
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(err)
+		require.NotEmpty(files)
+	})
+
+	t.Run("unwritable", func(t *testing.T) {
+		file := filepath.Join(tmp, "file")
+		require.NoError(ioutil.WriteFile(file, []byte{}, 0600))
+
+		_, err := prepareJournalDir(filepath.Join(file, "triecache"))
+		require.Error(err)
+	})
+}
@@ -0,0 +1,31 @@
+package evmstore
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreCacheEvictions(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := cachedStore()
+	limit := store.cfg.Cache.TxPositions
+
+	for i := 0; i < limit; i++ {
+		store.SetTxPosition(common.BytesToHash(bigendian.Uint64ToBytes(uint64(i))), TxPosition{Block: idx.Block(i)})
+	}
+	require.Equal(uint64(0), store.CacheStats()["TxPositions"].Evictions)
+
+	for i := limit; i < limit+10; i++ {
+		store.SetTxPosition(common.BytesToHash(bigendian.Uint64ToBytes(uint64(i))), TxPosition{Block: idx.Block(i)})
+	}
+	require.Equal(uint64(10), store.CacheStats()["TxPositions"].Evictions)
+	require.Equal(uint64(0), store.CacheStats()["Receipts"].Evictions)
+}
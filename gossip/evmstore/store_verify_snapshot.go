@@ -0,0 +1,99 @@
+package evmstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	// ErrSnapshotDiverged is returned if the snapshot doesn't match the trie
+	ErrSnapshotDiverged = errors.New("snapshot diverged from trie")
+)
+
+// VerifySnapshot checks that up to sampleSize random accounts of the snapshot match the trie at the root,
+// and that up to sampleSize random accounts of the trie match the snapshot. Storage slots aren't checked.
+// If a discrepancy is found, false is returned with the ErrSnapshotDiverged error describing the first one.
+func (s *Store) VerifySnapshot(root hash.Hash, sampleSize int) (bool, error) {
+	if s.table.Snaps == nil {
+		return false, snapshot.ErrNotConstructed
+	}
+	snap := s.table.Snaps.Snapshot(common.Hash(root))
+	if snap == nil {
+		return false, fmt.Errorf("snapshot of root %s isn't found", root.String())
+	}
+	tr, err := trie.New(common.Hash(root), s.table.EvmState.TrieDB())
+	if err != nil {
+		return false, err
+	}
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < sampleSize; i++ {
+		var seek common.Hash
+		rnd.Read(seek[:])
+
+		// snapshot -> trie
+		accIt, err := s.table.Snaps.AccountIterator(common.Hash(root), seek)
+		if err != nil {
+			return false, err
+		}
+		if accIt.Next() {
+			h, snapAcc := accIt.Hash(), common.CopyBytes(accIt.Account())
+			accIt.Release()
+			trieAcc, err := tr.TryGet(h.Bytes())
+			if err != nil {
+				return false, err
+			}
+			if err := compareSnapshotAccount(h, snapAcc, trieAcc); err != nil {
+				return false, err
+			}
+		} else {
+			err = accIt.Error()
+			accIt.Release()
+			if err != nil {
+				return false, err
+			}
+		}
+
+		// trie -> snapshot
+		trieIt := trie.NewIterator(tr.NodeIterator(seek.Bytes()))
+		if trieIt.Next() {
+			h := common.BytesToHash(trieIt.Key)
+			snapAcc, err := snap.AccountRLP(h)
+			if err != nil {
+				return false, err
+			}
+			if err := compareSnapshotAccount(h, snapAcc, trieIt.Value); err != nil {
+				return false, err
+			}
+		} else if trieIt.Err != nil {
+			return false, trieIt.Err
+		}
+	}
+	return true, nil
+}
+
+// compareSnapshotAccount compares the slim snapshot encoding of the account with the trie encoding
+func compareSnapshotAccount(h common.Hash, snapAcc, trieAcc []byte) error {
+	if len(snapAcc) == 0 {
+		return fmt.Errorf("%w: account %s is missing in snapshot", ErrSnapshotDiverged, h.String())
+	}
+	if len(trieAcc) == 0 {
+		return fmt.Errorf("%w: account %s is missing in trie", ErrSnapshotDiverged, h.String())
+	}
+	full, err := snapshot.FullAccountRLP(snapAcc)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(full, trieAcc) {
+		return fmt.Errorf("%w: account %s mismatches", ErrSnapshotDiverged, h.String())
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package evmstore
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreVerifySnapshot(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	openDB := reopenableDB()
+	cfg := LiteStoreConfig()
+	cfg.Cache.EvmSnap = 1024 * 1024
+	store := NewStore(openDB(), cfg)
+
+	statedb, err := store.StateDB(hash.Hash{})
+	require.NoError(err)
+	var addrs []common.Address
+	for i := int64(1); i <= 20; i++ {
+		addr := common.BigToAddress(big.NewInt(i))
+		addrs = append(addrs, addr)
+		statedb.AddBalance(addr, big.NewInt(i))
+	}
+	statedb.SetState(addrs[0], common.Hash{1}, common.Hash{2})
+	root, err := statedb.Commit(true)
+	require.NoError(err)
+	require.NoError(store.Commit(hash.Hash(root)))
+
+	_, err = store.VerifySnapshot(hash.Hash(root), 10)
+	require.Equal(snapshot.ErrNotConstructed, err)
+
+	require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+	ok, err := store.VerifySnapshot(hash.Hash(root), 10)
+	require.NoError(err)
+	require.True(ok)
+
+	_, err = store.VerifySnapshot(hash.Hash{1}, 10)
+	require.Error(err)
+	require.NoError(store.Close())
+
+	// reopens the store with the snapshot accounts modified on disk
+	diverge := func(modify func(store *Store, h common.Hash)) (bool, error) {
+		store := NewStore(openDB(), cfg)
+		defer store.Close()
+		for _, addr := range addrs {
+			modify(store, crypto.Keccak256Hash(addr.Bytes()))
+		}
+		require.NoError(store.InitEvmSnapshot(hash.Hash(root)))
+		return store.VerifySnapshot(hash.Hash(root), 10)
+	}
+
+	ok, err = diverge(func(store *Store, h common.Hash) {
+		wrong := snapshot.SlimAccountRLP(0, big.NewInt(1000), types.EmptyRootHash, crypto.Keccak256(nil))
+		rawdb.WriteAccountSnapshot(store.EvmTable(), h, wrong)
+	})
+	require.True(errors.Is(err, ErrSnapshotDiverged), err)
+	require.False(ok)
+
+	ok, err = diverge(func(store *Store, h common.Hash) {
+		rawdb.DeleteAccountSnapshot(store.EvmTable(), h)
+	})
+	require.True(errors.Is(err, ErrSnapshotDiverged), err)
+	require.False(ok)
+}
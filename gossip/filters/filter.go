@@ -32,7 +32,6 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/Fantom-foundation/go-opera/evmcore"
-	"github.com/Fantom-foundation/go-opera/topicsdb"
 )
 
 type Backend interface {
@@ -46,7 +45,9 @@ type Backend interface {
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) notify.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) notify.Subscription
 
-	EvmLogIndex() *topicsdb.Index
+	// FindLogsInBlocks returns log records of block range by pattern, 1st pattern element is an address.
+	// The backend limits the result size.
+	FindLogsInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash) ([]*types.Log, error)
 }
 
 // Filter can be used to retrieve and filter logs.
@@ -152,7 +153,7 @@ func (f *Filter) indexedLogs(ctx context.Context, begin, end idx.Block) ([]*type
 	pattern[0] = addresses
 	pattern = append(pattern, f.topics...)
 
-	logs, err := f.backend.EvmLogIndex().FindInBlocks(ctx, begin, end, pattern)
+	logs, err := f.backend.FindLogsInBlocks(ctx, begin, end, pattern)
 
 	return logs, err
 }
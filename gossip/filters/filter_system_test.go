@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -131,8 +132,8 @@ func (b *testBackend) SubscribeNewBlockEvent(ch chan<- evmcore.ChainHeadNotify)
 	return b.blocksFeed.Subscribe(ch)
 }
 
-func (b *testBackend) EvmLogIndex() *topicsdb.Index {
-	return b.logIndex
+func (b *testBackend) FindLogsInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash) ([]*types.Log, error) {
+	return b.logIndex.FindInBlocks(ctx, from, to, pattern)
 }
 
 // TestBlockSubscription tests if a block subscription returns block hashes for posted chain notify.
@@ -0,0 +1,88 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// reindexReadBlocks is a number of blocks which receipts are read at once while reindexing
+const reindexReadBlocks = 256
+
+// ReindexLogs indexes the logs of the blocks range [from, to] again, reading them from the stored receipts.
+// Logs of up to StoreConfig.EVM.LogsIndexBatchBlocks blocks are indexed in one DB write.
+// Blocks after the latest one are ignored. Reindexing of already indexed logs doesn't change the index.
+func (r *EvmStateReader) ReindexLogs(from, to idx.Block) error {
+	batchBlocks := r.store.cfg.EVM.LogsIndexBatchBlocks
+	var pending [][]*types.Log
+	flush := func() {
+		if len(pending) != 0 {
+			r.store.evm.IndexLogsBatch(pending...)
+			pending = pending[:0]
+		}
+	}
+
+	if latest := r.store.GetLatestBlockIndex(); to > latest {
+		to = latest
+	}
+	for start := from; start <= to; {
+		end := to
+		if end-start >= reindexReadBlocks {
+			end = start + reindexReadBlocks - 1
+		}
+		// receipts of the range are decoded in parallel
+		receiptsRange, err := r.store.evm.GetReceiptsRange(context.Background(), start, end)
+		if err != nil {
+			return err
+		}
+		for i, receipts := range receiptsRange {
+			logs, err := r.blockLogs(start+idx.Block(i), receipts)
+			if err != nil {
+				return err
+			}
+			if logs == nil {
+				continue
+			}
+			if batchBlocks <= 1 {
+				r.store.evm.IndexLogs(logs...)
+				continue
+			}
+			pending = append(pending, logs)
+			if len(pending) >= batchBlocks {
+				flush()
+			}
+		}
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	flush()
+	return nil
+}
+
+// blockLogs returns the logs of the block derived from its stored receipts, nil if the block has no receipts.
+func (r *EvmStateReader) blockLogs(n idx.Block, receipts types.Receipts) ([]*types.Log, error) {
+	if len(receipts) == 0 {
+		return nil, nil
+	}
+	block := r.GetBlock(common.Hash{}, uint64(n))
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", n)
+	}
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts index is corrupted, block=%d, receipts_num=%d, txs_num=%d", n, len(receipts), len(block.Transactions))
+	}
+	derived, err := r.deriveReceipts(block, receipts)
+	if err != nil {
+		return nil, err
+	}
+	logs := []*types.Log{}
+	for _, receipt := range derived {
+		logs = append(logs, receipt.Logs...)
+	}
+	return logs, nil
+}
@@ -1,10 +1,12 @@
 package gossip
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/dag"
@@ -12,6 +14,7 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/lachesis"
 	"github.com/Fantom-foundation/lachesis-base/utils/workers"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	notify "github.com/ethereum/go-ethereum/event"
@@ -40,6 +43,7 @@ import (
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
 	"github.com/Fantom-foundation/go-opera/gossip/filters"
 	"github.com/Fantom-foundation/go-opera/gossip/gasprice"
+	"github.com/Fantom-foundation/go-opera/gossip/sfcapi"
 	"github.com/Fantom-foundation/go-opera/inter"
 	"github.com/Fantom-foundation/go-opera/logger"
 	"github.com/Fantom-foundation/go-opera/opera"
@@ -58,6 +62,7 @@ type ServiceFeed struct {
 	newBlock        notify.Feed
 	newTxs          notify.Feed
 	newLogs         notify.Feed
+	stakerEvents    notify.Feed
 }
 
 func (f *ServiceFeed) SubscribeNewEpoch(ch chan<- idx.Epoch) notify.Subscription {
@@ -80,6 +85,10 @@ func (f *ServiceFeed) SubscribeNewLogs(ch chan<- []*types.Log) notify.Subscripti
 	return f.scope.Track(f.newLogs.Subscribe(ch))
 }
 
+func (f *ServiceFeed) SubscribeStakerEvents(ch chan<- sfcapi.StakerEvent) notify.Subscription {
+	return f.scope.Track(f.stakerEvents.Subscribe(ch))
+}
+
 type BlockProc struct {
 	SealerModule        blockproc.SealerModule
 	TxListenerModule    blockproc.TxListenerModule
@@ -357,9 +366,40 @@ func (s *Service) Start() error {
 
 	s.verWatcher.Start()
 
+	s.warmReceiptsCache()
+
 	return nil
 }
 
+// warmReceiptsCache loads receipts of the recent blocks into cache in background until service is stopped.
+func (s *Service) warmReceiptsCache() {
+	blocks := s.store.cfg.EVM.WarmupReceiptsBlocks
+	if blocks <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		start := time.Now()
+		err := s.store.EvmStore().WarmReceiptsCache(ctx, s.store.GetLatestBlockIndex(), blocks)
+		if err != nil {
+			log.Debug("Receipts cache warmup is interrupted", "err", err)
+			return
+		}
+		log.Debug("Receipts cache is warmed up", "blocks", blocks, "elapsed", common.PrettyDuration(time.Since(start)))
+	}()
+}
+
 // WaitBlockEnd waits until parallel block processing is complete (if any)
 func (s *Service) WaitBlockEnd() {
 	s.blockProcWg.Wait()
@@ -382,6 +422,10 @@ func (s *Service) Stop() error {
 
 	s.blockProcWg.Wait()
 	close(s.blockProcTasksDone)
+	// the journals are written into the flushable DB, so they must be written before the final commit
+	if err := s.store.EvmStore().Journal(); err != nil {
+		log.Error("Failed to journal EVM store", "err", err)
+	}
 	return s.store.Commit()
 }
 
@@ -0,0 +1,34 @@
+package sfcapi
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DelegationExport is a single record of the delegations export
+type DelegationExport struct {
+	Delegator common.Address  `json:"delegator"`
+	StakerID  idx.ValidatorID `json:"toStakerID"`
+	Amount    *hexutil.Big    `json:"amount"`
+}
+
+// StreamDelegationsJSON writes all stored SfcDelegations into w as newline-delimited JSON objects.
+// Delegations are encoded one by one, in the DB order (i.e. grouped by delegator).
+func (s *Store) StreamDelegationsJSON(w io.Writer) (err error) {
+	enc := json.NewEncoder(w)
+	s.ForEachSfcDelegation(func(it SfcDelegationAndID) {
+		if err != nil {
+			return
+		}
+		err = enc.Encode(&DelegationExport{
+			Delegator: it.ID.Delegator,
+			StakerID:  it.ID.StakerID,
+			Amount:    (*hexutil.Big)(it.Delegation.Amount),
+		})
+	})
+	return err
+}
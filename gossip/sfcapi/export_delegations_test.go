@@ -0,0 +1,51 @@
+package sfcapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStreamDelegationsJSON(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	written := map[DelegationID]*big.Int{
+		{common.Address{1}, 1}: big.NewInt(100),
+		{common.Address{1}, 2}: big.NewInt(200),
+		{common.Address{2}, 1}: big.NewInt(300),
+		{common.Address{3}, 5}: big.NewInt(1),
+	}
+	for id, amount := range written {
+		store.SetSfcDelegation(id, &SfcDelegation{Amount: amount})
+	}
+
+	count := 0
+	store.ForEachSfcDelegation(func(it SfcDelegationAndID) {
+		count++
+		require.Equal(written[it.ID], it.Delegation.Amount)
+		// callback receives a decoded copy
+		it.Delegation.Amount.SetUint64(0)
+	})
+	require.Equal(len(written), count)
+
+	buf := &bytes.Buffer{}
+	require.NoError(store.StreamDelegationsJSON(buf))
+	read := map[DelegationID]*big.Int{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var rec DelegationExport
+		require.NoError(json.Unmarshal(scanner.Bytes(), &rec))
+		read[DelegationID{rec.Delegator, rec.StakerID}] = rec.Amount.ToInt()
+	}
+	require.NoError(scanner.Err())
+	require.Equal(written, read)
+}
@@ -0,0 +1,77 @@
+package sfcapi
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// StakerExport is a single record of the stakers export
+type StakerExport struct {
+	StakerID         idx.ValidatorID `json:"id"`
+	Address          common.Address  `json:"address"`
+	CreatedEpoch     idx.Epoch       `json:"createdEpoch"`
+	CreatedTime      inter.Timestamp `json:"createdTime"`
+	DeactivatedEpoch idx.Epoch       `json:"deactivatedEpoch"`
+	DeactivatedTime  inter.Timestamp `json:"deactivatedTime"`
+	Status           uint64          `json:"status"`
+	SelfStake        *hexutil.Big    `json:"selfStake"`
+	TotalStake       *hexutil.Big    `json:"totalStake"`
+	// SelfStakeRatio is SelfStake / TotalStake, or 0 if staker has no stake
+	SelfStakeRatio float64 `json:"selfStakeRatio"`
+}
+
+// StreamStakersJSON writes stored SfcStakers into w as newline-delimited JSON objects.
+// Deactivated and punished stakers are skipped unless includeInactive is true.
+// Stakers are encoded one by one, only the per-staker stake totals are kept in memory.
+func (s *Store) StreamStakersJSON(w io.Writer, includeInactive bool) (err error) {
+	totalStakes := make(map[idx.ValidatorID]*big.Int)
+	s.ForEachSfcDelegation(func(it SfcDelegationAndID) {
+		total := totalStakes[it.ID.StakerID]
+		if total == nil {
+			total = new(big.Int)
+			totalStakes[it.ID.StakerID] = total
+		}
+		total.Add(total, it.Delegation.Amount)
+	})
+
+	enc := json.NewEncoder(w)
+	s.ForEachSfcStaker(func(it SfcStakerAndID) {
+		if err != nil {
+			return
+		}
+		if !includeInactive && !it.Staker.Ok() {
+			return
+		}
+		selfStake := new(big.Int)
+		if self := s.GetSfcDelegation(DelegationID{it.Staker.Address, it.StakerID}); self != nil {
+			selfStake = self.Amount
+		}
+		totalStake := totalStakes[it.StakerID]
+		if totalStake == nil {
+			totalStake = new(big.Int)
+		}
+		rec := StakerExport{
+			StakerID:         it.StakerID,
+			Address:          it.Staker.Address,
+			CreatedEpoch:     it.Staker.CreatedEpoch,
+			CreatedTime:      it.Staker.CreatedTime,
+			DeactivatedEpoch: it.Staker.DeactivatedEpoch,
+			DeactivatedTime:  it.Staker.DeactivatedTime,
+			Status:           it.Staker.Status,
+			SelfStake:        (*hexutil.Big)(selfStake),
+			TotalStake:       (*hexutil.Big)(totalStake),
+		}
+		if totalStake.Sign() > 0 {
+			rec.SelfStakeRatio, _ = new(big.Rat).SetFrac(selfStake, totalStake).Float64()
+		}
+		err = enc.Encode(&rec)
+	})
+	return err
+}
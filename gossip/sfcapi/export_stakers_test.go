@@ -0,0 +1,53 @@
+package sfcapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStreamStakersJSON(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 1, Address: common.Address{1}})
+	store.SetSfcStaker(2, &SfcStaker{CreatedEpoch: 1, Address: common.Address{2}})
+	store.SetSfcStaker(3, &SfcStaker{CreatedEpoch: 2, Address: common.Address{3}, DeactivatedEpoch: 3})
+	store.SetSfcDelegation(DelegationID{common.Address{1}, 1}, &SfcDelegation{Amount: big.NewInt(100)})
+	store.SetSfcDelegation(DelegationID{common.Address{9}, 1}, &SfcDelegation{Amount: big.NewInt(300)})
+	store.SetSfcDelegation(DelegationID{common.Address{3}, 3}, &SfcDelegation{Amount: big.NewInt(50)})
+
+	read := func(includeInactive bool) []StakerExport {
+		buf := &bytes.Buffer{}
+		require.NoError(store.StreamStakersJSON(buf, includeInactive))
+		var res []StakerExport
+		scanner := bufio.NewScanner(buf)
+		for scanner.Scan() {
+			var rec StakerExport
+			require.NoError(json.Unmarshal(scanner.Bytes(), &rec))
+			res = append(res, rec)
+		}
+		require.NoError(scanner.Err())
+		return res
+	}
+
+	all := read(true)
+	require.Len(all, len(store.GetSfcStakers()))
+
+	active := read(false)
+	require.Len(active, 2)
+	require.Equal(common.Address{1}, active[0].Address)
+	require.Equal(big.NewInt(100), active[0].SelfStake.ToInt())
+	require.Equal(big.NewInt(400), active[0].TotalStake.ToInt())
+	require.Equal(0.25, active[0].SelfStakeRatio)
+	require.Equal(0.0, active[1].SelfStakeRatio)
+	require.Equal(0, active[1].TotalStake.ToInt().Sign())
+}
@@ -12,7 +12,18 @@ import (
 	"github.com/Fantom-foundation/go-opera/topicsdb"
 )
 
-func ApplyGenesis(s *Store, index *topicsdb.Index) {
+// claimedRewardsEpoch returns the last epoch of claimed rewards, if the legacy claiming log carries it.
+// Otherwise, rewards are attributed to the epoch of claiming, which is an approximation.
+func claimedRewardsEpoch(l *types.Log, epoch idx.Epoch) idx.Epoch {
+	if len(l.Data) >= 96 {
+		return idx.Epoch(new(big.Int).SetBytes(l.Data[64:96]).Uint64())
+	}
+	return epoch
+}
+
+// ApplyGenesis indexes rewards claimed before genesis. Rewards are attributed to the genesis epoch
+// if legacy logs don't carry the epochs.
+func ApplyGenesis(s *Store, index *topicsdb.Index, epoch idx.Epoch) {
 	_ = index.ForEach(nil, [][]common.Hash{{sfc.ContractAddress.Hash()}, {Topics.ClaimedValidatorReward, Topics.ClaimedDelegationReward}}, func(l *types.Log) (gonext bool) {
 		if l.Topics[0] == Topics.ClaimedValidatorReward && len(l.Topics) > 1 && len(l.Data) >= 32 {
 			stakerID := idx.ValidatorID(new(big.Int).SetBytes(l.Topics[1][:]).Uint64())
@@ -24,6 +35,7 @@ func ApplyGenesis(s *Store, index *topicsdb.Index) {
 			}
 			s.IncDelegationClaimedRewards(DelegationID{staker.Address, stakerID}, reward)
 			s.IncStakerDelegationsClaimedRewards(stakerID, reward)
+			s.IncDelegatorEpochClaimedRewards(staker.Address, claimedRewardsEpoch(l, epoch), reward)
 		} else if l.Topics[0] == Topics.ClaimedDelegationReward && len(l.Topics) > 2 && len(l.Data) >= 32 {
 			address := common.BytesToAddress(l.Topics[1][12:])
 			stakerID := idx.ValidatorID(new(big.Int).SetBytes(l.Topics[2][:]).Uint64())
@@ -31,15 +43,44 @@ func ApplyGenesis(s *Store, index *topicsdb.Index) {
 
 			s.IncDelegationClaimedRewards(DelegationID{address, stakerID}, reward)
 			s.IncStakerDelegationsClaimedRewards(stakerID, reward)
+			s.IncDelegatorEpochClaimedRewards(address, claimedRewardsEpoch(l, epoch), reward)
 		}
 		return true
 	})
 }
 
-func OnNewLog(s *Store, l *types.Log) {
+// OnNewEpoch stores the validators of the new epoch, along with the current records of the stakers.
+// Validators without staker records are stored with empty records, so the epoch membership isn't lost.
+func OnNewEpoch(s *Store, epoch idx.Epoch, validators []idx.ValidatorID) {
+	vv := make([]SfcStakerAndID, 0, len(validators))
+	for _, stakerID := range validators {
+		staker := s.GetSfcStaker(stakerID)
+		if staker == nil {
+			staker = &SfcStaker{}
+		}
+		vv = append(vv, SfcStakerAndID{
+			StakerID: stakerID,
+			Staker:   staker,
+		})
+	}
+	s.SetEpochValidators(epoch, vv)
+}
+
+// OnNewLog indexes SFC log, emitted in the epoch by a block with the given time.
+// Returns a staker lifecycle event, if the log causes one. The event is returned after the index is updated.
+func OnNewLog(s *Store, l *types.Log, epoch idx.Epoch, blockTime inter.Timestamp) *StakerEvent {
 	if l.Address != sfc.ContractAddress {
-		return
+		return nil
 	}
+	stakerEvent := func(typ StakerEventType, stakerID idx.ValidatorID) *StakerEvent {
+		return &StakerEvent{
+			Type:      typ,
+			StakerID:  stakerID,
+			Epoch:     epoch,
+			BlockTime: blockTime,
+		}
+	}
+
 	// Add new stakers
 	if l.Topics[0] == Topics.CreatedValidator && len(l.Topics) > 2 && len(l.Data) >= 32 {
 		stakerID := idx.ValidatorID(new(big.Int).SetBytes(l.Topics[1][:]).Uint64())
@@ -52,6 +93,7 @@ func OnNewLog(s *Store, l *types.Log) {
 			CreatedTime:  inter.FromUnix(int64(createdTime.Uint64())),
 			Address:      address,
 		})
+		return stakerEvent(StakerCreated, stakerID)
 	}
 
 	// Add/increase delegations
@@ -63,10 +105,18 @@ func OnNewLog(s *Store, l *types.Log) {
 		prev := s.GetSfcDelegation(DelegationID{address, toStakerID})
 		if prev != nil {
 			amount.Add(amount, prev.Amount)
+		} else if s.cfg.EnableDelegatorTargetHistory {
+			s.AddDelegatorTarget(address, DelegatorTarget{epoch, toStakerID})
+		}
+		// the contract limits the resulting delegation, not the increment
+		if s.cfg.MinDelegation != nil && amount.Cmp(s.cfg.MinDelegation) < 0 {
+			s.Log.Error("Delegation is below the minimum, SFC index may be out of sync",
+				"delegator", address, "staker", toStakerID, "amount", amount, "min", s.cfg.MinDelegation)
 		}
 		s.SetSfcDelegation(DelegationID{address, toStakerID}, &SfcDelegation{
 			Amount: amount,
 		})
+		return stakerEvent(StakerStakeIncreased, toStakerID)
 	}
 
 	// Deactivate stakes
@@ -77,11 +127,12 @@ func OnNewLog(s *Store, l *types.Log) {
 
 		staker := s.GetSfcStaker(stakerID)
 		if staker == nil {
-			return
+			return nil
 		}
 		staker.DeactivatedEpoch = idx.Epoch(deactivatedEpoch.Uint64())
 		staker.DeactivatedTime = inter.FromUnix(int64(deactivatedTime.Uint64()))
 		s.SetSfcStaker(stakerID, staker)
+		return stakerEvent(StakerDeactivated, stakerID)
 	}
 
 	// Change status
@@ -91,7 +142,7 @@ func OnNewLog(s *Store, l *types.Log) {
 
 		staker := s.GetSfcStaker(stakerID)
 		if staker == nil {
-			return
+			return nil
 		}
 		staker.Status = status.Uint64()
 		s.SetSfcStaker(stakerID, staker)
@@ -105,9 +156,13 @@ func OnNewLog(s *Store, l *types.Log) {
 
 		delegation := s.GetSfcDelegation(id)
 		if delegation == nil {
-			return
+			return nil
 		}
 		delegation.Amount.Sub(delegation.Amount, amount)
+		if delegation.Amount.Sign() < 0 {
+			s.Log.Error("Undelegated amount exceeds indexed delegation", "staker", toStakerID, "delegator", address, "excess", new(big.Int).Neg(delegation.Amount))
+			delegation.Amount.SetUint64(0)
+		}
 		if delegation.Amount.Sign() > 0 {
 			s.SetSfcDelegation(id, delegation)
 		} else {
@@ -115,6 +170,22 @@ func OnNewLog(s *Store, l *types.Log) {
 		}
 	}
 
+	// Self-stake is withdrawn
+	if l.Topics[0] == Topics.Withdrawn && len(l.Topics) > 2 {
+		address := common.BytesToAddress(l.Topics[1][12:])
+		toStakerID := idx.ValidatorID(new(big.Int).SetBytes(l.Topics[2][:]).Uint64())
+
+		staker := s.GetSfcStaker(toStakerID)
+		if staker == nil || staker.Address != address {
+			return nil
+		}
+		if s.GetSfcDelegation(DelegationID{address, toStakerID}) != nil {
+			// self-stake is withdrawn partially
+			return nil
+		}
+		return stakerEvent(StakerWithdrawn, toStakerID)
+	}
+
 	// Track rewards
 	if (l.Topics[0] == Topics.ClaimedRewards || l.Topics[0] == Topics.RestakedRewards) && len(l.Topics) > 2 && len(l.Data) >= 96 {
 		address := common.BytesToAddress(l.Topics[1][12:])
@@ -126,5 +197,18 @@ func OnNewLog(s *Store, l *types.Log) {
 
 		s.IncDelegationClaimedRewards(DelegationID{address, stakerID}, reward)
 		s.IncStakerDelegationsClaimedRewards(stakerID, reward)
+		// the log doesn't carry epochs of the rewards, so they're attributed to the current epoch
+		s.IncDelegatorEpochClaimedRewards(address, epoch, reward)
+
+		// Track how often validators claim their own rewards
+		staker := s.GetSfcStaker(stakerID)
+		if staker != nil && staker.Address == address {
+			claims := s.incValidatorClaims(stakerID, blockTime)
+			if s.cfg.MaxValidatorClaimsPerDay != 0 && claims == s.cfg.MaxValidatorClaimsPerDay+1 {
+				// reported once per window
+				return stakerEvent(StakerClaimsRateExceeded, stakerID)
+			}
+		}
 	}
+	return nil
 }
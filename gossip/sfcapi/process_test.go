@@ -0,0 +1,301 @@
+package sfcapi
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/drivertype"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/opera/genesis/sfc"
+	"github.com/Fantom-foundation/go-opera/topicsdb"
+)
+
+func uint256(v uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(v)).Bytes()
+}
+
+func sfcLog(topics []common.Hash, data ...uint64) *types.Log {
+	l := &types.Log{
+		Address: sfc.ContractAddress,
+		Topics:  topics,
+	}
+	for _, v := range data {
+		l.Data = append(l.Data, uint256(v)...)
+	}
+	return l
+}
+
+func delegatedLog(delegator common.Address, stakerID idx.ValidatorID, amount uint64) *types.Log {
+	return sfcLog([]common.Hash{Topics.Delegated, delegator.Hash(), common.BytesToHash(uint256(uint64(stakerID)))}, amount)
+}
+
+func undelegatedLog(delegator common.Address, stakerID idx.ValidatorID, amount uint64) *types.Log {
+	return sfcLog([]common.Hash{Topics.Undelegated, delegator.Hash(), common.BytesToHash(uint256(uint64(stakerID))), {}}, amount)
+}
+
+func TestOnNewLogUndelegatedUnderflow(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	var logged []*log.Record
+	store.Log.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		if r.Lvl == log.LvlError {
+			logged = append(logged, r)
+		}
+		return nil
+	}))
+	id := DelegationID{common.Address{1}, 1}
+
+	OnNewLog(store, delegatedLog(id.Delegator, id.StakerID, 100), 1, 0)
+	require.Equal(big.NewInt(100), store.GetSfcDelegation(id).Amount)
+
+	OnNewLog(store, undelegatedLog(id.Delegator, id.StakerID, 40), 1, 0)
+	require.Equal(big.NewInt(60), store.GetSfcDelegation(id).Amount)
+	require.Len(logged, 0)
+
+	// undelegation exceeds the indexed amount, delegation is clamped to zero and erased
+	OnNewLog(store, undelegatedLog(id.Delegator, id.StakerID, 100), 1, 0)
+	require.Nil(store.GetSfcDelegation(id))
+	require.Len(logged, 1)
+	require.Contains(logged[0].Msg, "exceeds indexed delegation")
+	require.Contains(logged[0].Ctx, big.NewInt(40))
+}
+
+func TestClaimedRewardsByEpoch(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	addr1, addr2 := common.Address{1}, common.Address{2}
+	store.SetSfcStaker(1, &SfcStaker{Address: addr2})
+
+	// legacy logs carry the epochs range of the claimed rewards
+	index := topicsdb.New(memorydb.New())
+	legacyLogs := []*types.Log{
+		sfcLog([]common.Hash{Topics.ClaimedDelegationReward, addr1.Hash(), common.BytesToHash(uint256(1))}, 10, 1, 3),
+		sfcLog([]common.Hash{Topics.ClaimedDelegationReward, addr1.Hash(), common.BytesToHash(uint256(1))}, 20, 4, 5),
+		sfcLog([]common.Hash{Topics.ClaimedValidatorReward, common.BytesToHash(uint256(1))}, 30, 1, 5),
+	}
+	for i, l := range legacyLogs {
+		l.BlockNumber = uint64(i + 1)
+		require.NoError(index.Push(l))
+	}
+	ApplyGenesis(store, index, 6)
+
+	// new logs don't carry epochs, rewards are attributed to the current epoch
+	claimedLog := func(addr common.Address, rewards ...uint64) *types.Log {
+		return sfcLog([]common.Hash{Topics.ClaimedRewards, addr.Hash(), common.BytesToHash(uint256(1))}, rewards...)
+	}
+	OnNewLog(store, claimedLog(addr1, 1, 2, 3), 7, 0)
+	OnNewLog(store, claimedLog(addr1, 4, 0, 0), 7, 0)
+	OnNewLog(store, claimedLog(addr1, 0, 0, 5), 8, 0)
+	OnNewLog(store, claimedLog(addr2, 0, 7, 0), 8, 0)
+
+	require.Equal(map[idx.Epoch]*big.Int{
+		3: big.NewInt(10),
+		5: big.NewInt(20),
+		7: big.NewInt(10),
+		8: big.NewInt(5),
+	}, store.GetDelegatorClaimedRewardsByEpoch(addr1))
+	require.Equal(map[idx.Epoch]*big.Int{
+		5: big.NewInt(30),
+		8: big.NewInt(7),
+	}, store.GetDelegatorClaimedRewardsByEpoch(addr2))
+	require.Empty(store.GetDelegatorClaimedRewardsByEpoch(common.Address{3}))
+
+	// running totals are kept
+	require.Equal(big.NewInt(45), store.GetDelegationClaimedRewards(DelegationID{addr1, 1}))
+}
+
+func TestOnNewLogStakerEvents(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	stakerAddr, delegator := common.Address{1}, common.Address{2}
+	stakerIDHash := common.BytesToHash(uint256(1))
+	withdrawnLog := func(addr common.Address) *types.Log {
+		return sfcLog([]common.Hash{Topics.Withdrawn, addr.Hash(), stakerIDHash, {}}, 1)
+	}
+
+	for i, step := range []struct {
+		log    *types.Log
+		expect *StakerEvent
+	}{
+		{
+			log:    sfcLog([]common.Hash{Topics.CreatedValidator, stakerIDHash, stakerAddr.Hash()}, 3, 1000),
+			expect: &StakerEvent{Type: StakerCreated, StakerID: 1, Epoch: 3},
+		},
+		{
+			log:    delegatedLog(stakerAddr, 1, 100),
+			expect: &StakerEvent{Type: StakerStakeIncreased, StakerID: 1, Epoch: 3},
+		},
+		{
+			log:    delegatedLog(delegator, 1, 50),
+			expect: &StakerEvent{Type: StakerStakeIncreased, StakerID: 1, Epoch: 3},
+		},
+		{
+			log:    sfcLog([]common.Hash{Topics.DeactivatedValidator, stakerIDHash}, 4, 2000),
+			expect: &StakerEvent{Type: StakerDeactivated, StakerID: 1, Epoch: 3},
+		},
+		{
+			log: undelegatedLog(stakerAddr, 1, 60),
+		},
+		{
+			// self-stake is withdrawn partially
+			log: withdrawnLog(stakerAddr),
+		},
+		{
+			// not a self-stake
+			log: withdrawnLog(delegator),
+		},
+		{
+			log: undelegatedLog(stakerAddr, 1, 40),
+		},
+		{
+			log:    withdrawnLog(stakerAddr),
+			expect: &StakerEvent{Type: StakerWithdrawn, StakerID: 1, Epoch: 3},
+		},
+	} {
+		require.Equal(step.expect, OnNewLog(store, step.log, 3, 0), i)
+		// the index is updated before the event is returned
+		if step.expect != nil && step.expect.Type == StakerCreated {
+			require.NotNil(store.GetSfcStaker(1))
+		}
+		if step.expect != nil && step.expect.Type == StakerDeactivated {
+			require.Equal(idx.Epoch(4), store.GetSfcStaker(1).DeactivatedEpoch)
+		}
+	}
+}
+
+func TestOnNewLogValidatorClaimsRate(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := NewStore(memorydb.New(), Config{MaxValidatorClaimsPerDay: 3})
+	validator, delegator := common.Address{1}, common.Address{2}
+	store.SetSfcStaker(1, &SfcStaker{Address: validator})
+
+	claimedLog := func(addr common.Address) *types.Log {
+		return sfcLog([]common.Hash{Topics.ClaimedRewards, addr.Hash(), common.BytesToHash(uint256(1))}, 1, 0, 0)
+	}
+	start := inter.FromUnix(1000)
+	hour := inter.Timestamp(time.Hour)
+
+	require.Zero(store.GetLastClaimTime(1))
+
+	// delegators' claims aren't counted
+	for i := 0; i < 10; i++ {
+		require.Nil(OnNewLog(store, claimedLog(delegator), 1, start))
+	}
+	require.Zero(store.GetLastClaimTime(1))
+
+	// rapid claims trip the threshold once
+	for i := 0; i < 3; i++ {
+		require.Nil(OnNewLog(store, claimedLog(validator), 1, start+inter.Timestamp(i)*hour))
+	}
+	require.Equal(&StakerEvent{
+		Type:      StakerClaimsRateExceeded,
+		StakerID:  1,
+		Epoch:     2,
+		BlockTime: start + 3*hour,
+	}, OnNewLog(store, claimedLog(validator), 2, start+3*hour))
+	require.Nil(OnNewLog(store, claimedLog(validator), 2, start+4*hour))
+	require.Equal(start+4*hour, store.GetLastClaimTime(1))
+	require.Equal(uint32(5), store.GetClaimsPerDay(1, start+5*hour))
+
+	// the window is restarted after a day
+	require.Zero(store.GetClaimsPerDay(1, start+24*hour))
+	require.Nil(OnNewLog(store, claimedLog(validator), 3, start+25*hour))
+	require.Equal(uint32(1), store.GetClaimsPerDay(1, start+25*hour))
+}
+
+func TestOnNewLogMinDelegation(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := NewStore(memorydb.New(), Config{MinDelegation: big.NewInt(100)})
+	var logged []*log.Record
+	store.Log.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		if r.Lvl == log.LvlError {
+			logged = append(logged, r)
+		}
+		return nil
+	}))
+	id := DelegationID{common.Address{1}, 1}
+
+	OnNewLog(store, delegatedLog(id.Delegator, id.StakerID, 100), 1, 0)
+	require.Len(logged, 0)
+	// the resulting delegation is checked, not the increment
+	OnNewLog(store, delegatedLog(id.Delegator, id.StakerID, 1), 1, 0)
+	require.Len(logged, 0)
+
+	// the check is diagnostic only, the delegation is indexed anyway
+	small := DelegationID{common.Address{2}, 1}
+	OnNewLog(store, delegatedLog(small.Delegator, small.StakerID, 99), 1, 0)
+	require.Len(logged, 1)
+	require.Contains(logged[0].Msg, "below the minimum")
+	require.Equal(big.NewInt(99), store.GetSfcDelegation(small).Amount)
+	require.Equal(big.NewInt(101), store.GetSfcDelegation(id).Amount)
+
+	// the check is disabled by default
+	require.Nil(DefaultConfig().MinDelegation)
+}
+
+func TestOnNewLogDelegatorTargetHistory(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := NewStore(memorydb.New(), Config{EnableDelegatorTargetHistory: true})
+	addr := common.Address{1}
+
+	// initial delegation, increasing it doesn't change the target
+	OnNewLog(store, delegatedLog(addr, 1, 100), 1, 0)
+	OnNewLog(store, delegatedLog(addr, 1, 100), 2, 0)
+	require.Equal([]DelegatorTarget{{1, 1}}, store.GetDelegatorTargetHistory(addr))
+
+	// full withdrawal and re-delegation to a different validator
+	OnNewLog(store, undelegatedLog(addr, 1, 200), 3, 0)
+	OnNewLog(store, delegatedLog(addr, 2, 100), 4, 0)
+	// re-delegation to the first validator
+	OnNewLog(store, delegatedLog(addr, 1, 100), 5, 0)
+	require.Equal([]DelegatorTarget{{1, 1}, {4, 2}, {5, 1}}, store.GetDelegatorTargetHistory(addr))
+	require.Empty(store.GetDelegatorTargetHistory(common.Address{2}))
+
+	// disabled
+	store = memStore()
+	OnNewLog(store, delegatedLog(addr, 1, 100), 1, 0)
+	require.Empty(store.GetDelegatorTargetHistory(addr))
+}
+
+func TestOnNewLogDoublesignStatus(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	addr := common.Address{1}
+	OnNewLog(store, sfcLog([]common.Hash{Topics.CreatedValidator, common.BytesToHash(uint256(1)), addr.Hash()}, 1, 1), 1, 0)
+	OnNewLog(store, delegatedLog(addr, 1, 100), 1, 0)
+
+	// the status is set by the contract, DOUBLESIGN_BIT marks a cheater
+	OnNewLog(store, sfcLog([]common.Hash{Topics.ChangedValidatorStatus, common.BytesToHash(uint256(1))}, drivertype.DoublesignBit), 2, 0)
+	staker := store.GetSfcStaker(1)
+	require.True(staker.IsCheater())
+	require.False(staker.Ok())
+	require.Equal([]idx.ValidatorID{1}, store.GetStakersByStatus()[StakerStatusCheater])
+
+	detailed, err := store.GetSfcDelegationDetailed(DelegationID{addr, 1})
+	require.NoError(err)
+	require.True(detailed.TargetIsCheater)
+	require.False(detailed.IsActive)
+}
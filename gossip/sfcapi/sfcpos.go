@@ -12,6 +12,7 @@ import (
 //event ChangedValidatorStatus(uint256 indexed validatorID, uint256 status);
 //event Delegated(address indexed delegator, uint256 indexed toValidatorID, uint256 amount);
 //event Undelegated(address indexed delegator, uint256 indexed toValidatorID, uint256 indexed wrID, uint256 amount);
+//event Withdrawn(address indexed delegator, uint256 indexed toValidatorID, uint256 indexed wrID, uint256 amount);
 //event ClaimedRewards(address indexed delegator, uint256 indexed toValidatorID, uint256 rewards);
 
 var (
@@ -26,6 +27,7 @@ var (
 		ChangedValidatorStatus  common.Hash
 		Delegated               common.Hash
 		Undelegated             common.Hash
+		Withdrawn               common.Hash
 	}{
 		ClaimedRewards:          crypto.Keccak256Hash([]byte("ClaimedRewards(address,uint256,uint256,uint256,uint256)")),
 		RestakedRewards:         crypto.Keccak256Hash([]byte("RestakedRewards(address,uint256,uint256,uint256,uint256)")),
@@ -36,5 +38,6 @@ var (
 		ChangedValidatorStatus:  crypto.Keccak256Hash([]byte("ChangedValidatorStatus(uint256,uint256)")),
 		Delegated:               crypto.Keccak256Hash([]byte("Delegated(address,uint256,uint256)")),
 		Undelegated:             crypto.Keccak256Hash([]byte("Undelegated(address,uint256,uint256,uint256)")),
+		Withdrawn:               crypto.Keccak256Hash([]byte("Withdrawn(address,uint256,uint256,uint256)")),
 	}
 )
@@ -1,6 +1,8 @@
 package sfcapi
 
 import (
+	"math/big"
+
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/table"
 
@@ -8,8 +10,29 @@ import (
 	"github.com/Fantom-foundation/go-opera/utils/rlpstore"
 )
 
+// Config is a config of the SFC index
+type Config struct {
+	// MaxValidatorClaimsPerDay is a number of self-claims of rewards by a validator within a day,
+	// exceeding which is reported as a StakerClaimsRateExceeded event (0 means no limit)
+	MaxValidatorClaimsPerDay uint32
+	// MinDelegation is a minimum delegation amount enforced by the SFC contract.
+	// Smaller indexed delegations (after an increase) are logged as a sign of the index desync (nil means no check, which is the default)
+	MinDelegation *big.Int
+	// EnableDelegatorTargetHistory enables indexing of the validators each delegator delegated to, by epochs
+	EnableDelegatorTargetHistory bool
+}
+
+// DefaultConfig returns the default SFC index config.
+func DefaultConfig() Config {
+	return Config{
+		MaxValidatorClaimsPerDay: 24,
+	}
+}
+
 // Store is a node persistent storage working over physical key-value database.
 type Store struct {
+	cfg Config
+
 	mainDB kvdb.Store
 	table  struct {
 		GasPowerRefund kvdb.Store `table:"R"`
@@ -17,10 +40,18 @@ type Store struct {
 		Validators  kvdb.Store `table:"1"`
 		Stakers     kvdb.Store `table:"2"`
 		Delegations kvdb.Store `table:"3"`
+		// StakersByAddr is an address -> StakerID index
+		StakersByAddr kvdb.Store `table:"4"`
 
 		DelegationOldRewards        kvdb.Store `table:"6"`
 		StakerOldRewards            kvdb.Store `table:"7"`
 		StakerDelegationsOldRewards kvdb.Store `table:"8"`
+		// DelegatorEpochRewards is an (address, epoch) -> claimed rewards index
+		DelegatorEpochRewards kvdb.Store `table:"9"`
+		// ValidatorClaimsRate is a StakerID -> ValidatorClaimsRate index
+		ValidatorClaimsRate kvdb.Store `table:"a"`
+		// DelegatorTargets is an (address, epoch, StakerID) -> nil index of new delegations
+		DelegatorTargets kvdb.Store `table:"b"`
 	}
 
 	rlp rlpstore.Helper
@@ -29,8 +60,9 @@ type Store struct {
 }
 
 // NewStore creates store over key-value db.
-func NewStore(mainDB kvdb.Store) *Store {
+func NewStore(mainDB kvdb.Store, cfg Config) *Store {
 	s := &Store{
+		cfg:      cfg,
 		mainDB:   mainDB,
 		Instance: logger.MakeInstance(),
 		rlp:      rlpstore.Helper{logger.MakeInstance()},
@@ -0,0 +1,51 @@
+package sfcapi
+
+import (
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// claimsRateWindow is a duration of the window within which validator claims are counted
+const claimsRateWindow = inter.Timestamp(24 * time.Hour)
+
+// GetValidatorClaimsRate returns stored claims rate of a validator, or nil if it has never claimed its rewards
+func (s *Store) GetValidatorClaimsRate(stakerID idx.ValidatorID) *ValidatorClaimsRate {
+	w, _ := s.rlp.Get(s.table.ValidatorClaimsRate, stakerID.Bytes(), &ValidatorClaimsRate{}).(*ValidatorClaimsRate)
+
+	return w
+}
+
+// GetLastClaimTime returns time of the last claim of own rewards by a validator, or 0 if it has never claimed
+func (s *Store) GetLastClaimTime(stakerID idx.ValidatorID) inter.Timestamp {
+	rate := s.GetValidatorClaimsRate(stakerID)
+	if rate == nil {
+		return 0
+	}
+	return rate.LastClaimTime
+}
+
+// GetClaimsPerDay returns number of claims of own rewards by a validator within the day-long window, which is current at the time
+func (s *Store) GetClaimsPerDay(stakerID idx.ValidatorID, now inter.Timestamp) uint32 {
+	rate := s.GetValidatorClaimsRate(stakerID)
+	if rate == nil || now >= rate.WindowStart+claimsRateWindow {
+		return 0
+	}
+	return rate.WindowClaims
+}
+
+// incValidatorClaims counts a claim of own rewards by a validator and returns the number of claims within the current window
+func (s *Store) incValidatorClaims(stakerID idx.ValidatorID, now inter.Timestamp) uint32 {
+	rate := s.GetValidatorClaimsRate(stakerID)
+	if rate == nil || now >= rate.WindowStart+claimsRateWindow {
+		rate = &ValidatorClaimsRate{
+			WindowStart: now,
+		}
+	}
+	rate.LastClaimTime = now
+	rate.WindowClaims++
+	s.rlp.Set(s.table.ValidatorClaimsRate, stakerID.Bytes(), rate)
+	return rate.WindowClaims
+}
@@ -0,0 +1,83 @@
+package sfcapi
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+// ValidatorDelegationShare is a share of a validator in the network-wide delegation
+type ValidatorDelegationShare struct {
+	StakerID  idx.ValidatorID
+	Delegated *big.Int
+	// Percent is Delegated / total delegated to active stakers, in percents
+	Percent float64
+}
+
+// DelegationConcentration is a distribution of delegations among the active stakers
+type DelegationConcentration struct {
+	Validators []ValidatorDelegationShare
+	Total      *big.Int
+	// Gini is a Gini coefficient of the delegated amounts: 0 if they're equal, close to 1 if one staker has everything
+	Gini float64
+}
+
+// GetDelegationConcentration returns delegated amount of every active staker and its share of the network-wide delegation.
+// Deactivated stakers and cheaters are excluded, as well as delegations to them.
+func (s *Store) GetDelegationConcentration() DelegationConcentration {
+	delegated := make(map[idx.ValidatorID]*big.Int)
+	s.ForEachSfcStaker(func(it SfcStakerAndID) {
+		if it.Staker.Ok() && !it.Staker.IsCheater() {
+			delegated[it.StakerID] = new(big.Int)
+		}
+	})
+	s.ForEachSfcDelegation(func(it SfcDelegationAndID) {
+		if amount, ok := delegated[it.ID.StakerID]; ok {
+			amount.Add(amount, it.Delegation.Amount)
+		}
+	})
+
+	res := DelegationConcentration{
+		Validators: make([]ValidatorDelegationShare, 0, len(delegated)),
+		Total:      new(big.Int),
+	}
+	for stakerID, amount := range delegated {
+		res.Validators = append(res.Validators, ValidatorDelegationShare{
+			StakerID:  stakerID,
+			Delegated: amount,
+		})
+		res.Total.Add(res.Total, amount)
+	}
+	if res.Total.Sign() == 0 {
+		sort.Slice(res.Validators, func(i, j int) bool {
+			return res.Validators[i].StakerID < res.Validators[j].StakerID
+		})
+		return res
+	}
+
+	// Gini coefficient over the amounts in ascending order: sum((2i - n - 1) * x_i) / (n * sum(x_i)), i = 1..n
+	sort.Slice(res.Validators, func(i, j int) bool {
+		a, b := res.Validators[i], res.Validators[j]
+		if cmp := a.Delegated.Cmp(b.Delegated); cmp != 0 {
+			return cmp < 0
+		}
+		return a.StakerID < b.StakerID
+	})
+	n := int64(len(res.Validators))
+	weighted := new(big.Int)
+	total := new(big.Rat).SetInt(res.Total)
+	for i := range res.Validators {
+		v := &res.Validators[i]
+		coef := big.NewInt(2*int64(i+1) - n - 1)
+		weighted.Add(weighted, coef.Mul(coef, v.Delegated))
+		v.Percent, _ = new(big.Rat).Quo(new(big.Rat).SetInt(v.Delegated), total).Float64()
+		v.Percent *= 100
+	}
+	res.Gini, _ = new(big.Rat).SetFrac(weighted, new(big.Int).Mul(big.NewInt(n), res.Total)).Float64()
+
+	sort.Slice(res.Validators, func(i, j int) bool {
+		return res.Validators[i].StakerID < res.Validators[j].StakerID
+	})
+	return res
+}
@@ -0,0 +1,60 @@
+package sfcapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetDelegationConcentration(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	setup := func(amounts ...int64) *Store {
+		store := memStore()
+		for i, amount := range amounts {
+			stakerID := idx.ValidatorID(i + 1)
+			store.SetSfcStaker(stakerID, &SfcStaker{CreatedEpoch: 1})
+			store.SetSfcDelegation(DelegationID{common.Address{1}, stakerID}, &SfcDelegation{Amount: big.NewInt(amount)})
+		}
+		// inactive stakers aren't counted
+		store.SetSfcStaker(100, &SfcStaker{CreatedEpoch: 1, DeactivatedEpoch: 2})
+		store.SetSfcDelegation(DelegationID{common.Address{2}, 100}, &SfcDelegation{Amount: big.NewInt(1e6)})
+		store.SetSfcStaker(101, &SfcStaker{CreatedEpoch: 1, Status: ForkBit})
+		store.SetSfcDelegation(DelegationID{common.Address{2}, 101}, &SfcDelegation{Amount: big.NewInt(1e6)})
+		return store
+	}
+
+	uniform := setup(100, 100, 100, 100).GetDelegationConcentration()
+	require.Len(uniform.Validators, 4)
+	require.Equal(big.NewInt(400), uniform.Total)
+	require.InDelta(0, uniform.Gini, 1e-9)
+	for i, v := range uniform.Validators {
+		require.Equal(idx.ValidatorID(i+1), v.StakerID)
+		require.Equal(big.NewInt(100), v.Delegated)
+		require.InDelta(25, v.Percent, 1e-9)
+	}
+
+	skewed := setup(10, 10, 10, 970).GetDelegationConcentration()
+	require.Len(skewed.Validators, 4)
+	require.Equal(big.NewInt(1000), skewed.Total)
+	require.InDelta(97, skewed.Validators[3].Percent, 1e-9)
+	require.InDelta(1, skewed.Validators[0].Percent, 1e-9)
+	require.InDelta(0.72, skewed.Gini, 1e-9)
+	require.Greater(skewed.Gini, uniform.Gini)
+
+	// more even distribution is less concentrated
+	milder := setup(100, 200, 300, 400).GetDelegationConcentration()
+	require.Greater(milder.Gini, uniform.Gini)
+	require.Less(milder.Gini, skewed.Gini)
+
+	empty := memStore().GetDelegationConcentration()
+	require.Empty(empty.Validators)
+	require.Equal(0, empty.Total.Sign())
+	require.Equal(0.0, empty.Gini)
+}
@@ -0,0 +1,43 @@
+package sfcapi
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+// DelegatorIssueType is a kind of inconsistency of an indexed delegation
+type DelegatorIssueType uint8
+
+const (
+	// DelegatorIssueMissingStaker is reported if the delegation target isn't an indexed staker
+	DelegatorIssueMissingStaker DelegatorIssueType = iota
+	// DelegatorIssueZeroAmount is reported if the delegation amount is zero, while such delegations are erased
+	DelegatorIssueZeroAmount
+)
+
+// DelegatorIssue is an inconsistency of an indexed delegation
+type DelegatorIssue struct {
+	Type DelegatorIssueType
+	ID   DelegationID
+}
+
+// ValidateDelegatorConsistency checks the indexed delegations against the indexed stakers.
+// It's a diagnostic full scan, intended to detect a silent corruption of the SFC index.
+// Note: staker aggregates (e.g. DelegatedMe) aren't indexed, so the amounts are checked individually.
+func (s *Store) ValidateDelegatorConsistency() []DelegatorIssue {
+	var issues []DelegatorIssue
+	stakerExists := make(map[idx.ValidatorID]bool)
+	s.ForEachSfcDelegation(func(it SfcDelegationAndID) {
+		exists, ok := stakerExists[it.ID.StakerID]
+		if !ok {
+			exists = s.HasSfcStaker(it.ID.StakerID)
+			stakerExists[it.ID.StakerID] = exists
+		}
+		if !exists {
+			issues = append(issues, DelegatorIssue{DelegatorIssueMissingStaker, it.ID})
+		}
+		if it.Delegation.Amount == nil || it.Delegation.Amount.Sign() == 0 {
+			issues = append(issues, DelegatorIssue{DelegatorIssueZeroAmount, it.ID})
+		}
+	})
+	return issues
+}
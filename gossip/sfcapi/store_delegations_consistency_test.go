@@ -0,0 +1,36 @@
+package sfcapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreValidateDelegatorConsistency(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 1})
+	store.SetSfcDelegation(DelegationID{common.Address{1}, 1}, &SfcDelegation{Amount: big.NewInt(100)})
+	store.SetSfcDelegation(DelegationID{common.Address{2}, 1}, &SfcDelegation{Amount: big.NewInt(200)})
+	require.Empty(store.ValidateDelegatorConsistency())
+
+	orphan := DelegationID{common.Address{3}, 2}
+	store.SetSfcDelegation(orphan, &SfcDelegation{Amount: big.NewInt(100)})
+	zero := DelegationID{common.Address{4}, 1}
+	store.SetSfcDelegation(zero, &SfcDelegation{Amount: big.NewInt(0)})
+
+	require.Equal([]DelegatorIssue{
+		{DelegatorIssueMissingStaker, orphan},
+		{DelegatorIssueZeroAmount, zero},
+	}, store.ValidateDelegatorConsistency())
+
+	store.SetSfcStaker(2, &SfcStaker{CreatedEpoch: 2})
+	store.DelSfcDelegation(zero)
+	require.Empty(store.ValidateDelegatorConsistency())
+}
@@ -0,0 +1,31 @@
+package sfcapi
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddDelegatorTarget stores that the delegator started delegating to the validator in the epoch
+func (s *Store) AddDelegatorTarget(delegator common.Address, target DelegatorTarget) {
+	key := append(append(delegator.Bytes(), target.Epoch.Bytes()...), target.ToStakerID.Bytes()...)
+	if err := s.table.DelegatorTargets.Put(key, []byte{}); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// GetDelegatorTargetHistory returns validators which the delegator started delegating to, ordered by epochs.
+// A new entry is indexed on a first delegation to a validator, or on a delegation after a full undelegation.
+// Only delegations indexed with Config.EnableDelegatorTargetHistory are returned.
+func (s *Store) GetDelegatorTargetHistory(delegator common.Address) []DelegatorTarget {
+	it := s.table.DelegatorTargets.NewIterator(delegator.Bytes(), nil)
+	defer it.Release()
+	res := make([]DelegatorTarget, 0)
+	for it.Next() {
+		key := it.Key()[common.AddressLength:]
+		res = append(res, DelegatorTarget{
+			Epoch:      idx.BytesToEpoch(key[:4]),
+			ToStakerID: idx.BytesToValidatorID(key[4:]),
+		})
+	}
+	return res
+}
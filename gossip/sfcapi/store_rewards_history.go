@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // GetDelegationClaimedRewards returns sum of claimed rewards in past, by this delegation
@@ -59,3 +60,31 @@ func (s *Store) IncStakerDelegationsClaimedRewards(stakerID idx.ValidatorID, dif
 	amount.Add(amount, diff)
 	s.SetStakerDelegationsClaimedRewards(stakerID, amount)
 }
+
+// IncDelegatorEpochClaimedRewards increments sum of rewards claimed by the delegator, attributed to the epoch
+func (s *Store) IncDelegatorEpochClaimedRewards(delegator common.Address, epoch idx.Epoch, diff *big.Int) {
+	key := append(delegator.Bytes(), epoch.Bytes()...)
+	amount := new(big.Int)
+	if prev, err := s.table.DelegatorEpochRewards.Get(key); err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	} else if prev != nil {
+		amount.SetBytes(prev)
+	}
+	amount.Add(amount, diff)
+	err := s.table.DelegatorEpochRewards.Put(key, amount.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// GetDelegatorClaimedRewardsByEpoch returns sums of rewards claimed by the delegator, by epochs the rewards are attributed to
+func (s *Store) GetDelegatorClaimedRewardsByEpoch(delegator common.Address) map[idx.Epoch]*big.Int {
+	it := s.table.DelegatorEpochRewards.NewIterator(delegator.Bytes(), nil)
+	defer it.Release()
+	res := make(map[idx.Epoch]*big.Int)
+	for it.Next() {
+		epoch := idx.BytesToEpoch(it.Key()[common.AddressLength:])
+		res[epoch] = new(big.Int).SetBytes(it.Value())
+	}
+	return res
+}
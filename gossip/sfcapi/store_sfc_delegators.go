@@ -1,11 +1,18 @@
 package sfcapi
 
 import (
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+var (
+	// ErrDelegationNotFound is returned if requested delegation isn't indexed
+	ErrDelegationNotFound = errors.New("delegation not found")
+)
+
 // SetSfcDelegation stores SfcDelegation
 func (s *Store) SetSfcDelegation(id DelegationID, v *SfcDelegation) {
 	s.rlp.Set(s.table.Delegations, id.Bytes(), v)
@@ -68,3 +75,24 @@ func (s *Store) GetSfcDelegation(id DelegationID) *SfcDelegation {
 
 	return w
 }
+
+// GetSfcDelegationDetailed returns stored SfcDelegation along with the status of the target staker.
+// Note: staker status is read as stored by SFC, i.e. in the raw contract format
+func (s *Store) GetSfcDelegationDetailed(id DelegationID) (*SfcDelegationDetailed, error) {
+	delegation := s.GetSfcDelegation(id)
+	if delegation == nil {
+		return nil, ErrDelegationNotFound
+	}
+	res := &SfcDelegationDetailed{
+		SfcDelegation: *delegation,
+		ID:            id,
+	}
+	staker := s.GetSfcStaker(id.StakerID)
+	if staker == nil {
+		return res, nil
+	}
+	res.TargetIsCheater = staker.IsCheater()
+	res.TargetDeactivatedEpoch = staker.DeactivatedEpoch
+	res.IsActive = delegation.Amount.Sign() > 0 && staker.Ok()
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package sfcapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetSfcDelegationDetailed(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	delegator := common.Address{1}
+	active := DelegationID{delegator, 1}
+	deactivated := DelegationID{delegator, 2}
+	cheater := DelegationID{delegator, 3}
+
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 1})
+	store.SetSfcStaker(2, &SfcStaker{CreatedEpoch: 1, DeactivatedEpoch: 5})
+	store.SetSfcStaker(3, &SfcStaker{CreatedEpoch: 2, Status: ForkBit})
+	for _, id := range []DelegationID{active, deactivated, cheater} {
+		store.SetSfcDelegation(id, &SfcDelegation{Amount: big.NewInt(100)})
+	}
+
+	got, err := store.GetSfcDelegationDetailed(active)
+	require.NoError(err)
+	require.Equal(active, got.ID)
+	require.Equal(big.NewInt(100), got.Amount)
+	require.True(got.IsActive)
+	require.False(got.TargetIsCheater)
+
+	got, err = store.GetSfcDelegationDetailed(deactivated)
+	require.NoError(err)
+	require.False(got.IsActive)
+	require.Equal(idx.Epoch(5), got.TargetDeactivatedEpoch)
+
+	got, err = store.GetSfcDelegationDetailed(cheater)
+	require.NoError(err)
+	require.False(got.IsActive)
+	require.True(got.TargetIsCheater)
+
+	_, err = store.GetSfcDelegationDetailed(DelegationID{common.Address{2}, 1})
+	require.Equal(ErrDelegationNotFound, err)
+}
@@ -1,9 +1,14 @@
 package sfcapi
 
 import (
+	"sort"
+
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/Fantom-foundation/go-opera/inter"
 )
 
 // SetEpochValidators stores EpochValidators
@@ -21,19 +26,110 @@ func (s *Store) HasEpochValidator(epoch idx.Epoch, stakerID idx.ValidatorID) boo
 	return ok
 }
 
+// hasEpochValidators returns true if validators of the epoch are stored
+func (s *Store) hasEpochValidators(epoch idx.Epoch) bool {
+	it := s.table.Validators.NewIterator(epoch.Bytes(), nil)
+	defer it.Release()
+	return it.Next()
+}
+
+// GetValidatorMembership returns whether staker is a validator in the epoch and in the next one.
+// If validators of the next epoch aren't stored yet, NextEpochActive is false and the result is provisional.
+func (s *Store) GetValidatorMembership(epoch idx.Epoch, stakerID idx.ValidatorID) ValidatorMembership {
+	res := ValidatorMembership{
+		CurrentEpochActive: s.HasEpochValidator(epoch, stakerID),
+	}
+	if !s.hasEpochValidators(epoch + 1) {
+		res.Provisional = true
+		return res
+	}
+	res.NextEpochActive = s.HasEpochValidator(epoch+1, stakerID)
+	return res
+}
+
+// AvailableValidatorEpochs returns the first and the last epochs for which validators are stored.
+// Epochs in between may be missing.
+func (s *Store) AvailableValidatorEpochs() (min, max idx.Epoch, ok bool) {
+	var start []byte
+	for {
+		it := s.table.Validators.NewIterator(nil, start)
+		found := it.Next()
+		var epoch idx.Epoch
+		if found {
+			epoch = idx.BytesToEpoch(it.Key()[:4])
+		}
+		it.Release()
+		if !found {
+			return
+		}
+		if !ok {
+			min, ok = epoch, true
+		}
+		max = epoch
+		// skip the rest of validators of the epoch
+		start = (epoch + 1).Bytes()
+	}
+}
+
 // SetSfcStaker stores SfcStaker
 func (s *Store) SetSfcStaker(stakerID idx.ValidatorID, v *SfcStaker) {
 	s.rlp.Set(s.table.Stakers, stakerID.Bytes(), v)
+
+	err := s.table.StakersByAddr.Put(v.Address.Bytes(), stakerID.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
 }
 
 // DelSfcStaker deletes SfcStaker
 func (s *Store) DelSfcStaker(stakerID idx.ValidatorID) {
+	staker := s.GetSfcStaker(stakerID)
+	if staker != nil {
+		if id, ok := s.GetStakerIDByAddress(staker.Address); ok && id == stakerID {
+			err := s.table.StakersByAddr.Delete(staker.Address.Bytes())
+			if err != nil {
+				s.Log.Crit("Failed to erase key-value", "err", err)
+			}
+		}
+	}
+
 	err := s.table.Stakers.Delete(stakerID.Bytes())
 	if err != nil {
 		s.Log.Crit("Failed to erase staker")
 	}
 }
 
+// GetStakerIDByAddress returns ID of the staker with the given address
+func (s *Store) GetStakerIDByAddress(addr common.Address) (idx.ValidatorID, bool) {
+	b, err := s.table.StakersByAddr.Get(addr.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if b == nil {
+		return 0, false
+	}
+	return idx.BytesToValidatorID(b), true
+}
+
+// GetStakerByAddress returns stored SfcStaker with the given address
+func (s *Store) GetStakerByAddress(addr common.Address) *SfcStaker {
+	stakerID, ok := s.GetStakerIDByAddress(addr)
+	if !ok {
+		return nil
+	}
+	return s.GetSfcStaker(stakerID)
+}
+
+// RebuildStakersByAddressIndex fills address -> StakerID index from all stored SfcStakers
+func (s *Store) RebuildStakersByAddressIndex() {
+	s.ForEachSfcStaker(func(it SfcStakerAndID) {
+		err := s.table.StakersByAddr.Put(it.Staker.Address.Bytes(), it.StakerID.Bytes())
+		if err != nil {
+			s.Log.Crit("Failed to put key-value", "err", err)
+		}
+	})
+}
+
 // ForEachSfcStaker iterates all stored SfcStakers
 func (s *Store) ForEachSfcStaker(do func(SfcStakerAndID)) {
 	it := s.table.Stakers.NewIterator(nil, nil)
@@ -50,6 +146,47 @@ func (s *Store) GetSfcStakers() []SfcStakerAndID {
 	return stakers
 }
 
+// GetStakersByStatus returns IDs of all stored SfcStakers grouped by their statuses.
+// Statuses without stakers are omitted.
+func (s *Store) GetStakersByStatus() map[StakerStatus][]idx.ValidatorID {
+	res := make(map[StakerStatus][]idx.ValidatorID)
+	s.ForEachSfcStaker(func(it SfcStakerAndID) {
+		var status StakerStatus
+		switch {
+		case it.Staker.IsCheater():
+			status = StakerStatusCheater
+		case it.Staker.DeactivatedEpoch != 0:
+			status = StakerStatusPendingWithdrawal
+			if s.GetSfcDelegation(DelegationID{it.Staker.Address, it.StakerID}) == nil {
+				status = StakerStatusWithdrawn
+			}
+		case !it.Staker.Ok():
+			status = StakerStatusOffline
+		default:
+			status = StakerStatusActive
+		}
+		res[status] = append(res[status], it.StakerID)
+	})
+	return res
+}
+
+// GetStakersCreatedBetween returns stored SfcStakers created within [from, to] time range, which pass the filter.
+// Nil filter passes all the stakers, including inactive ones.
+// Note: it's a scan over all stakers, as they aren't indexed by time.
+func (s *Store) GetStakersCreatedBetween(from, to inter.Timestamp, filter func(*SfcStaker) bool) []SfcStakerAndID {
+	stakers := make([]SfcStakerAndID, 0, 20)
+	s.ForEachSfcStaker(func(it SfcStakerAndID) {
+		if it.Staker.CreatedTime < from || it.Staker.CreatedTime > to {
+			return
+		}
+		if filter != nil && !filter(it.Staker) {
+			return
+		}
+		stakers = append(stakers, it)
+	})
+	return stakers
+}
+
 // GetEpochValidators returns all stored EpochValidators on the epoch
 func (s *Store) GetEpochValidators(epoch idx.Epoch) []SfcStakerAndID {
 	it := s.table.Validators.NewIterator(epoch.Bytes(), nil)
@@ -93,3 +230,52 @@ func (s *Store) HasSfcStaker(stakerID idx.ValidatorID) bool {
 	}
 	return ok
 }
+
+// GetStakerCreatedEpoch returns the epoch in which staker was created.
+// Note: the full staker record is read and decoded
+func (s *Store) GetStakerCreatedEpoch(stakerID idx.ValidatorID) (idx.Epoch, bool) {
+	staker := s.GetSfcStaker(stakerID)
+	if staker == nil {
+		return 0, false
+	}
+	return staker.CreatedEpoch, true
+}
+
+// GetSfcStakersByIDs returns stored SfcStakers by IDs. Missing stakers are omitted.
+// The stakers are read in a single pass over the stakers table in the order of IDs, instead of a DB read per staker.
+// Note: the records are read bypassing GetSfcStaker, so a stakers cache (if any) must be checked here as well
+func (s *Store) GetSfcStakersByIDs(ids []idx.ValidatorID) map[idx.ValidatorID]*SfcStaker {
+	res := make(map[idx.ValidatorID]*SfcStaker, len(ids))
+	if len(ids) == 0 {
+		return res
+	}
+	sorted := make([]idx.ValidatorID, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	it := s.table.Stakers.NewIterator(nil, sorted[0].Bytes())
+	defer it.Release()
+	next := 0
+	for next < len(sorted) && it.Next() {
+		stakerID := idx.BytesToValidatorID(it.Key())
+		// skip the requested stakers which are missing
+		for next < len(sorted) && sorted[next] < stakerID {
+			next++
+		}
+		if next == len(sorted) || sorted[next] != stakerID {
+			continue
+		}
+		staker := &SfcStaker{}
+		if err := rlp.DecodeBytes(it.Value(), staker); err != nil {
+			s.Log.Crit("Failed to decode rlp", "err", err)
+		}
+		res[stakerID] = staker
+		next++
+	}
+	if err := it.Error(); err != nil {
+		s.Log.Crit("Failed to iterate stakers", "err", err)
+	}
+	return res
+}
@@ -0,0 +1,207 @@
+package sfcapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetStakerCreatedEpoch(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 7})
+
+	epoch, ok := store.GetStakerCreatedEpoch(1)
+	require.True(ok)
+	require.Equal(idx.Epoch(7), epoch)
+
+	_, ok = store.GetStakerCreatedEpoch(2)
+	require.False(ok)
+}
+
+func TestStoreGetStakerByAddress(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	addr := common.Address{1}
+	store.SetSfcStaker(5, &SfcStaker{CreatedEpoch: 2, Address: addr})
+
+	stakerID, ok := store.GetStakerIDByAddress(addr)
+	require.True(ok)
+	require.Equal(idx.ValidatorID(5), stakerID)
+	require.Equal(idx.Epoch(2), store.GetStakerByAddress(addr).CreatedEpoch)
+
+	_, ok = store.GetStakerIDByAddress(common.Address{2})
+	require.False(ok)
+	require.Nil(store.GetStakerByAddress(common.Address{2}))
+
+	store.DelSfcStaker(5)
+	_, ok = store.GetStakerIDByAddress(addr)
+	require.False(ok)
+
+	// index is recovered from the stakers table
+	store.rlp.Set(store.table.Stakers, idx.ValidatorID(6).Bytes(), &SfcStaker{Address: addr})
+	_, ok = store.GetStakerIDByAddress(addr)
+	require.False(ok)
+	store.RebuildStakersByAddressIndex()
+	stakerID, ok = store.GetStakerIDByAddress(addr)
+	require.True(ok)
+	require.Equal(idx.ValidatorID(6), stakerID)
+}
+
+func TestStoreGetSfcStakersByIDs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 1})
+	store.SetSfcStaker(3, &SfcStaker{CreatedEpoch: 3})
+
+	got := store.GetSfcStakersByIDs([]idx.ValidatorID{1, 2, 3, 3, 4})
+	require.Len(got, 2)
+	require.Equal(idx.Epoch(1), got[1].CreatedEpoch)
+	require.Equal(idx.Epoch(3), got[3].CreatedEpoch)
+
+	// not sorted IDs, a staker after the last requested one
+	store.SetSfcStaker(5, &SfcStaker{CreatedEpoch: 5})
+	got = store.GetSfcStakersByIDs([]idx.ValidatorID{3, 0, 1})
+	require.Len(got, 2)
+	require.Equal(idx.Epoch(1), got[1].CreatedEpoch)
+	require.Equal(idx.Epoch(3), got[3].CreatedEpoch)
+
+	require.Empty(store.GetSfcStakersByIDs(nil))
+}
+
+func TestStoreGetValidatorMembership(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetEpochValidators(1, []SfcStakerAndID{
+		{StakerID: 1, Staker: &SfcStaker{}},
+		{StakerID: 2, Staker: &SfcStaker{}},
+	})
+
+	// next epoch validators aren't sealed yet
+	require.Equal(ValidatorMembership{
+		CurrentEpochActive: true,
+		Provisional:        true,
+	}, store.GetValidatorMembership(1, 1))
+
+	// seal epoch 1, staker 2 leaves the validators set and staker 3 joins it
+	store.SetEpochValidators(2, []SfcStakerAndID{
+		{StakerID: 1, Staker: &SfcStaker{}},
+		{StakerID: 3, Staker: &SfcStaker{}},
+	})
+	require.Equal(ValidatorMembership{
+		CurrentEpochActive: true,
+		NextEpochActive:    true,
+	}, store.GetValidatorMembership(1, 1))
+	require.Equal(ValidatorMembership{
+		CurrentEpochActive: true,
+	}, store.GetValidatorMembership(1, 2))
+	require.Equal(ValidatorMembership{
+		NextEpochActive: true,
+	}, store.GetValidatorMembership(1, 3))
+
+	// after the seal, the new current epoch has no next validators yet
+	require.Equal(ValidatorMembership{
+		CurrentEpochActive: true,
+		Provisional:        true,
+	}, store.GetValidatorMembership(2, 3))
+	require.Equal(ValidatorMembership{
+		Provisional: true,
+	}, store.GetValidatorMembership(2, 2))
+}
+
+func TestStoreAvailableValidatorEpochs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	_, _, ok := store.AvailableValidatorEpochs()
+	require.False(ok)
+
+	for _, epoch := range []idx.Epoch{7, 3, 100, 4} {
+		store.SetEpochValidators(epoch, []SfcStakerAndID{
+			{StakerID: 1, Staker: &SfcStaker{}},
+			{StakerID: 2, Staker: &SfcStaker{}},
+		})
+	}
+	min, max, ok := store.AvailableValidatorEpochs()
+	require.True(ok)
+	require.Equal(idx.Epoch(3), min)
+	require.Equal(idx.Epoch(100), max)
+}
+
+func TestStoreGetStakersCreatedBetween(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	store.SetSfcStaker(1, &SfcStaker{CreatedTime: 100})
+	store.SetSfcStaker(2, &SfcStaker{CreatedTime: 200})
+	store.SetSfcStaker(3, &SfcStaker{CreatedTime: 250, DeactivatedEpoch: 5})
+	store.SetSfcStaker(4, &SfcStaker{CreatedTime: 300})
+	store.SetSfcStaker(5, &SfcStaker{CreatedTime: 301})
+
+	ids := func(stakers []SfcStakerAndID) []idx.ValidatorID {
+		res := make([]idx.ValidatorID, 0, len(stakers))
+		for _, it := range stakers {
+			res = append(res, it.StakerID)
+		}
+		return res
+	}
+
+	// boundaries are inclusive
+	require.Equal([]idx.ValidatorID{2, 3, 4}, ids(store.GetStakersCreatedBetween(200, 300, nil)))
+	require.Equal([]idx.ValidatorID{1}, ids(store.GetStakersCreatedBetween(0, 199, nil)))
+	require.Empty(store.GetStakersCreatedBetween(302, inter.Timestamp(1e18), nil))
+	require.Empty(store.GetStakersCreatedBetween(300, 200, nil))
+
+	active := func(staker *SfcStaker) bool {
+		return staker.Ok()
+	}
+	require.Equal([]idx.ValidatorID{2, 4}, ids(store.GetStakersCreatedBetween(200, 300, active)))
+}
+
+func TestStoreGetStakersByStatus(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := memStore()
+	require.Empty(store.GetStakersByStatus())
+
+	selfStake := func(stakerID idx.ValidatorID, addr common.Address) {
+		store.SetSfcDelegation(DelegationID{addr, stakerID}, &SfcDelegation{Amount: big.NewInt(100)})
+	}
+	store.SetSfcStaker(1, &SfcStaker{CreatedEpoch: 1, Address: common.Address{1}})
+	selfStake(1, common.Address{1})
+	store.SetSfcStaker(2, &SfcStaker{CreatedEpoch: 1, Address: common.Address{2}})
+	selfStake(2, common.Address{2})
+	store.SetSfcStaker(3, &SfcStaker{CreatedEpoch: 1, DeactivatedEpoch: 5, Address: common.Address{3}})
+	selfStake(3, common.Address{3})
+	store.SetSfcStaker(4, &SfcStaker{CreatedEpoch: 1, DeactivatedEpoch: 5, Address: common.Address{4}})
+	store.SetSfcStaker(5, &SfcStaker{CreatedEpoch: 1, Status: ForkBit, Address: common.Address{5}})
+	selfStake(5, common.Address{5})
+	store.SetSfcStaker(6, &SfcStaker{CreatedEpoch: 1, DeactivatedEpoch: 5, Status: ForkBit, Address: common.Address{6}})
+	store.SetSfcStaker(7, &SfcStaker{CreatedEpoch: 1, Status: OfflineBit, Address: common.Address{7}})
+	selfStake(7, common.Address{7})
+
+	require.Equal(map[StakerStatus][]idx.ValidatorID{
+		StakerStatusActive:            {1, 2},
+		StakerStatusPendingWithdrawal: {3},
+		StakerStatusWithdrawn:         {4},
+		StakerStatusCheater:           {5, 6},
+		StakerStatusOffline:           {7},
+	}, store.GetStakersByStatus())
+}
@@ -0,0 +1,9 @@
+package sfcapi
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+)
+
+func memStore() *Store {
+	return NewStore(memorydb.New(), DefaultConfig())
+}
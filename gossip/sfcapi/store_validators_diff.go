@@ -0,0 +1,62 @@
+package sfcapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+var (
+	// ErrEpochValidatorsNotFound is returned if validators of the requested epoch aren't stored
+	ErrEpochValidatorsNotFound = errors.New("epoch validators not found")
+)
+
+// ValidatorSetDiff returns validators which are in the epochB but not in the epochA (joined),
+// and validators which are in the epochA but not in the epochB (left).
+// Both result slices are ordered by StakerID.
+func (s *Store) ValidatorSetDiff(epochA, epochB idx.Epoch) (joined, left []idx.ValidatorID, err error) {
+	a, err := s.epochValidatorIDs(epochA)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := s.epochValidatorIDs(epochB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	joined = make([]idx.ValidatorID, 0)
+	left = make([]idx.ValidatorID, 0)
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			left = append(left, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			joined = append(joined, b[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return joined, left, nil
+}
+
+// epochValidatorIDs returns sorted IDs of the epoch validators
+func (s *Store) epochValidatorIDs(epoch idx.Epoch) ([]idx.ValidatorID, error) {
+	vv := s.GetEpochValidators(epoch)
+	if len(vv) == 0 {
+		return nil, fmt.Errorf("%w: epoch %d", ErrEpochValidatorsNotFound, epoch)
+	}
+	ids := make([]idx.ValidatorID, len(vv))
+	for i, v := range vv {
+		ids[i] = v.StakerID
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids, nil
+}
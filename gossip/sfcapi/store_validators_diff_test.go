@@ -0,0 +1,78 @@
+package sfcapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreValidatorSetDiff(t *testing.T) {
+	logger.SetTestMode(t)
+
+	setValidators := func(store *Store, epoch idx.Epoch, ids ...idx.ValidatorID) {
+		vv := make([]SfcStakerAndID, len(ids))
+		for i, id := range ids {
+			vv[i] = SfcStakerAndID{StakerID: id, Staker: &SfcStaker{}}
+		}
+		store.SetEpochValidators(epoch, vv)
+	}
+
+	for name, tc := range map[string]struct {
+		a, b         []idx.ValidatorID
+		joined, left []idx.ValidatorID
+	}{
+		"overlapping": {
+			a:      []idx.ValidatorID{1, 2, 3, 5},
+			b:      []idx.ValidatorID{6, 2, 4, 3},
+			joined: []idx.ValidatorID{4, 6},
+			left:   []idx.ValidatorID{1, 5},
+		},
+		"disjoint": {
+			a:      []idx.ValidatorID{3, 1},
+			b:      []idx.ValidatorID{2, 4},
+			joined: []idx.ValidatorID{2, 4},
+			left:   []idx.ValidatorID{1, 3},
+		},
+		"identical": {
+			a:      []idx.ValidatorID{1, 2, 3},
+			b:      []idx.ValidatorID{1, 2, 3},
+			joined: []idx.ValidatorID{},
+			left:   []idx.ValidatorID{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			store := memStore()
+			setValidators(store, 1, tc.a...)
+			setValidators(store, 2, tc.b...)
+
+			joined, left, err := store.ValidatorSetDiff(1, 2)
+			require.NoError(err)
+			require.Equal(tc.joined, joined)
+			require.Equal(tc.left, left)
+
+			// reversed order of epochs swaps the result
+			joined, left, err = store.ValidatorSetDiff(2, 1)
+			require.NoError(err)
+			require.Equal(tc.left, joined)
+			require.Equal(tc.joined, left)
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		require := require.New(t)
+
+		store := memStore()
+		setValidators(store, 1, 1, 2)
+
+		_, _, err := store.ValidatorSetDiff(1, 2)
+		require.True(errors.Is(err, ErrEpochValidatorsNotFound))
+		_, _, err = store.ValidatorSetDiff(3, 1)
+		require.True(errors.Is(err, ErrEpochValidatorsNotFound))
+	})
+}
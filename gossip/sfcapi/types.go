@@ -7,11 +7,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/drivertype"
 )
 
 var (
-	// ForkBit is set if staker has a confirmed pair of fork events
-	ForkBit = uint64(1)
+	// ForkBit is set if staker has a confirmed pair of fork events, it's the DOUBLESIGN_BIT of the SFC contract
+	ForkBit = drivertype.DoublesignBit
 	// OfflineBit is set if staker has didn't have confirmed events for a long time
 	OfflineBit = uint64(1 << 8)
 	// CheaterMask is a combination of severe misbehavings
@@ -72,6 +73,63 @@ type SfcStakerAndID struct {
 	Staker   *SfcStaker
 }
 
+// ValidatorMembership tells whether staker is a validator in the current and the next epochs
+type ValidatorMembership struct {
+	CurrentEpochActive bool
+	NextEpochActive    bool
+	// Provisional is true if validators of the next epoch aren't known yet
+	Provisional bool
+}
+
+// StakerStatus is a lifecycle status of a staker
+type StakerStatus uint8
+
+const (
+	// StakerStatusActive is a status of a staker which is neither deactivated nor punished
+	StakerStatusActive StakerStatus = iota
+	// StakerStatusPendingWithdrawal is a status of a deactivated staker which hasn't withdrawn the self-stake yet
+	StakerStatusPendingWithdrawal
+	// StakerStatusWithdrawn is a status of a deactivated staker which has withdrawn the self-stake, but is still indexed
+	StakerStatusWithdrawn
+	// StakerStatusCheater is a status of a staker punished for a severe misbehaving, regardless of deactivation
+	StakerStatusCheater
+	// StakerStatusOffline is a status of a not deactivated staker punished for a non-severe misbehaving, e.g. being offline
+	StakerStatusOffline
+)
+
+// StakerEventType is a type of staker lifecycle transition
+type StakerEventType uint8
+
+const (
+	// StakerCreated is emitted when a new staker is created
+	StakerCreated StakerEventType = iota
+	// StakerStakeIncreased is emitted when stake is delegated to a staker
+	StakerStakeIncreased
+	// StakerDeactivated is emitted when a staker is deactivated and prepared to withdraw
+	StakerDeactivated
+	// StakerWithdrawn is emitted when a staker has withdrawn all the self-stake
+	StakerWithdrawn
+	// StakerClaimsRateExceeded is emitted when a staker claims its own rewards more often than Config.MaxValidatorClaimsPerDay
+	StakerClaimsRateExceeded
+)
+
+// StakerEvent is a notification about staker lifecycle transition
+type StakerEvent struct {
+	Type      StakerEventType
+	StakerID  idx.ValidatorID
+	Epoch     idx.Epoch
+	BlockTime inter.Timestamp
+}
+
+// ValidatorClaimsRate tracks how often a validator claims its own rewards
+type ValidatorClaimsRate struct {
+	LastClaimTime inter.Timestamp
+	// WindowStart is a time of the first claim within the current day-long window
+	WindowStart inter.Timestamp
+	// WindowClaims is a number of claims since WindowStart
+	WindowClaims uint32
+}
+
 // SfcDelegation is the node-side representation of SFC delegation
 type SfcDelegation struct {
 	Amount *big.Int
@@ -102,6 +160,12 @@ func BytesToDelegationID(bb []byte) DelegationID {
 	return DelegationID{delegator, stakerID}
 }
 
+// DelegatorTarget is a validator which a delegator started delegating to in the epoch
+type DelegatorTarget struct {
+	Epoch      idx.Epoch
+	ToStakerID idx.ValidatorID
+}
+
 // SfcDelegationAndID is pair SfcDelegation + address
 type SfcDelegationAndID struct {
 	Delegation *SfcDelegation
@@ -123,3 +187,14 @@ type EpochStats struct {
 func (s *EpochStats) Duration() inter.Timestamp {
 	return s.End - s.Start
 }
+
+// SfcDelegationDetailed is SfcDelegation enriched with the status of the target staker
+type SfcDelegationDetailed struct {
+	SfcDelegation
+	ID DelegationID
+
+	TargetIsCheater        bool
+	TargetDeactivatedEpoch idx.Epoch
+	// IsActive is true if delegation is non-zero and target staker is neither deactivated nor a cheater
+	IsActive bool
+}
@@ -1,10 +1,14 @@
 package gossip
 
 import (
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"sync/atomic"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
@@ -46,9 +50,17 @@ type Store struct {
 		// API-only
 		BlockHashes kvdb.Store `table:"B"`
 		SfcAPI      kvdb.Store `table:"S"`
+		// ValidatorKeys is a validator public key -> ValidatorID index
+		ValidatorKeys kvdb.Store `table:"k"`
 	}
 
-	prevFlushTime time.Time
+	prevFlushTime  time.Time
+	prevFlushBlock idx.Block
+
+	// closed is set once the store is closed, accessed atomically
+	closed uint32
+
+	compactor compactor
 
 	epochStore atomic.Value
 
@@ -58,8 +70,10 @@ type Store struct {
 		Blocks          *wlru.Cache  `cache:"-"` // store by pointer
 		BlockHashes     *wlru.Cache  `cache:"-"` // store by pointer
 		EvmBlocks       *wlru.Cache  `cache:"-"` // store by pointer
+		TxSenders       *wlru.Cache  `cache:"-"` // store by value
 		BlockEpochState atomic.Value // store by value
 		HighestLamport  atomic.Value // store by value
+		GenesisRoot     atomic.Value // store by value
 	}
 
 	rlp rlpstore.Helper
@@ -67,6 +81,28 @@ type Store struct {
 	logger.Instance
 }
 
+// mainDBTables returns prefixes of the main DB tables, which are shared with the EVM store.
+func mainDBTables() []string {
+	field, _ := reflect.TypeOf((*Store)(nil)).Elem().FieldByName("table")
+	prefixes := make([]string, 0, field.Type.NumField())
+	for i := 0; i < field.Type.NumField(); i++ {
+		if prefix, ok := field.Type.Field(i).Tag.Lookup("table"); ok {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// trieCleanJournal returns the trie clean cache journal directory namespaced by the network ID,
+// so the caches of different networks don't clobber each other. The network is unknown until genesis is applied,
+// so the journal is used since the next start in such case.
+func (s *Store) trieCleanJournal() string {
+	if s.cfg.EVM.TrieCleanJournal == "" || s.GetGenesisHash() == nil {
+		return ""
+	}
+	return filepath.Join(s.cfg.EVM.TrieCleanJournal, strconv.FormatUint(s.GetRules().NetworkID, 10))
+}
+
 // NewMemStore creates store over memory map.
 func NewMemStore() *Store {
 	mems := memorydb.NewProducer("")
@@ -78,6 +114,9 @@ func NewMemStore() *Store {
 
 // NewStore creates store over key-value db.
 func NewStore(dbs kvdb.FlushableDBProducer, cfg StoreConfig) *Store {
+	if err := cfg.Validate(); err != nil {
+		log.Crit("Invalid store config", "err", err)
+	}
 	mainDB, err := dbs.OpenDB("gossip")
 	if err != nil {
 		log.Crit("Filed to open DB", "name", "gossip", "err", err)
@@ -90,22 +129,29 @@ func NewStore(dbs kvdb.FlushableDBProducer, cfg StoreConfig) *Store {
 		dbs:           dbs,
 		cfg:           cfg,
 		async:         newAsyncStore(asyncDB),
-		mainDB:        mainDB,
 		Instance:      logger.MakeInstance(),
 		prevFlushTime: time.Now(),
 		rlp:           rlpstore.Helper{logger.MakeInstance()},
 	}
+	s.mainDB = &deletionsCounter{
+		Store:           mainDB,
+		deletedKeysSize: &s.compactor.deletedKeysSize,
+	}
 
 	table.MigrateTables(&s.table, s.mainDB)
 
 	s.initCache()
-	s.evm = evmstore.NewStore(s.mainDB, cfg.EVM)
-	s.sfcapi = sfcapi.NewStore(s.table.SfcAPI)
+	evmCfg := cfg.EVM
+	evmCfg.TrieCleanJournal = s.trieCleanJournal()
+	s.evm = evmstore.NewStore(s.mainDB, evmCfg)
+	s.sfcapi = sfcapi.NewStore(s.table.SfcAPI, cfg.SfcAPI)
 
 	if err := s.migrateData(); err != nil {
 		s.Log.Crit("Failed to migrate Gossip DB", "err", err)
 	}
 
+	s.startCompaction()
+
 	return s
 }
 
@@ -120,18 +166,28 @@ func (s *Store) initCache() {
 	eventsHeadersNum := s.cfg.Cache.EventsNum
 	eventsHeadersCacheSize := nominalSize * uint(eventsHeadersNum)
 	s.cache.EventsHeaders = s.makeCache(eventsHeadersCacheSize, eventsHeadersNum)
+
+	s.cache.TxSenders = s.makeCache(nominalSize*uint(s.cfg.Cache.TxSendersNum), s.cfg.Cache.TxSendersNum)
 }
 
-// Close closes underlying database.
+// Close closes underlying database. Not committed changes are dropped, so the EVM store must be journaled
+// and the store committed before Close on a normal shutdown. It's safe to call Close multiple times.
 func (s *Store) Close() {
+	if !atomic.CompareAndSwapUint32(&s.closed, 0, 1) {
+		return
+	}
+
 	setnil := func() interface{} {
 		return nil
 	}
 
+	s.stopCompaction()
+
 	table.MigrateTables(&s.table, nil)
 	table.MigrateCaches(&s.cache, setnil)
 
-	_ = s.mainDB.Close()
+	// closes the main DB as well
+	_ = s.evm.Close()
 	s.async.Close()
 	s.sfcapi.Close()
 	_ = s.closeEpochStore()
@@ -145,7 +201,8 @@ func (s *Store) IsCommitNeeded(epochSealing bool) bool {
 		size /= 2
 	}
 	return time.Since(s.prevFlushTime) > period ||
-		s.dbs.NotFlushedSizeEst() > size
+		s.dbs.NotFlushedSizeEst() > size ||
+		(s.cfg.MaxNonFlushedBlocks != 0 && s.GetLatestBlockIndex() >= s.prevFlushBlock+s.cfg.MaxNonFlushedBlocks)
 }
 
 // commitEVM commits EVM storage
@@ -183,7 +240,11 @@ func (s *Store) Init() error {
 
 // Commit changes.
 func (s *Store) Commit() error {
+	s.compactor.mu.Lock()
+	defer s.compactor.mu.Unlock()
+
 	s.prevFlushTime = time.Now()
+	s.prevFlushBlock = s.GetLatestBlockIndex()
 	flushID := bigendian.Uint64ToBytes(uint64(time.Now().UnixNano()))
 	// Flush the DBs
 	s.FlushBlockEpochState()
@@ -193,6 +254,8 @@ func (s *Store) Commit() error {
 		es.FlushHeads()
 		es.FlushLastEvents()
 	}
+	// the logs buffered by the paused logs indexing would be lost on a crash otherwise
+	s.evm.FlushPausedLogs()
 	return s.dbs.Flush(flushID)
 }
 
@@ -52,6 +52,18 @@ func (s *Store) GetBlock(n idx.Block) *inter.Block {
 	return block
 }
 
+// StateRootByNumber returns the state root of the block. Returns false if the block is unknown.
+func (s *Store) StateRootByNumber(n idx.Block) (hash.Hash, bool) {
+	if cached := s.evm.GetCachedEvmBlock(n); cached != nil {
+		return hash.Hash(cached.Root), true
+	}
+	block := s.GetBlock(n)
+	if block == nil {
+		return hash.Hash{}, false
+	}
+	return block.Root, true
+}
+
 func (s *Store) ForEachBlock(fn func(index idx.Block, block *inter.Block)) {
 	it := s.table.Blocks.NewIterator(nil, nil)
 	defer it.Release()
@@ -130,3 +142,21 @@ func (s *Store) GetGenesisTime() inter.Timestamp {
 	}
 	return block.Time
 }
+
+// GenesisRoot returns the EVM state root of the genesis block. Returns false if genesis isn't applied yet.
+// The root is cached after the first successful read, as genesis never changes.
+func (s *Store) GenesisRoot() (hash.Hash, bool) {
+	if cached := s.cache.GenesisRoot.Load(); cached != nil {
+		return cached.(hash.Hash), true
+	}
+	n := s.GetGenesisBlockIndex()
+	if n == nil {
+		return hash.Hash{}, false
+	}
+	block := s.GetBlock(*n)
+	if block == nil {
+		return hash.Hash{}, false
+	}
+	s.cache.GenesisRoot.Store(block.Root)
+	return block.Root, true
+}
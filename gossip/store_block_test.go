@@ -0,0 +1,72 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreStateRootByNumber(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	root1 := hash.Hash(hash.FakeHash(1))
+	root2 := hash.Hash(hash.FakeHash(2))
+
+	store := NewMemStore()
+	defer store.Close()
+
+	store.SetBlock(1, &inter.Block{Root: root1})
+	store.EvmStore().SetCachedEvmBlock(2, &evmcore.EvmBlock{
+		EvmHeader: evmcore.EvmHeader{
+			Root:   common.Hash(root2),
+			TxHash: common.Hash{1},
+		},
+	})
+
+	root, ok := store.StateRootByNumber(1)
+	require.True(ok)
+	require.Equal(root1, root)
+
+	root, ok = store.StateRootByNumber(2)
+	require.True(ok)
+	require.Equal(root2, root)
+
+	_, ok = store.StateRootByNumber(3)
+	require.False(ok)
+}
+
+func TestStoreGenesisRoot(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	root := hash.Hash(hash.FakeHash(1))
+
+	store := NewMemStore()
+	defer store.Close()
+
+	_, ok := store.GenesisRoot()
+	require.False(ok)
+
+	// genesis block index is set, but the block isn't written yet
+	store.SetGenesisBlockIndex(0)
+	_, ok = store.GenesisRoot()
+	require.False(ok)
+
+	store.SetBlock(0, &inter.Block{Root: root})
+	got, ok := store.GenesisRoot()
+	require.True(ok)
+	require.Equal(root, got)
+
+	// the root is cached
+	store.SetBlock(0, &inter.Block{Root: hash.Hash(hash.FakeHash(2))})
+	got, ok = store.GenesisRoot()
+	require.True(ok)
+	require.Equal(root, got)
+}
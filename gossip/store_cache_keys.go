@@ -0,0 +1,24 @@
+package gossip
+
+import (
+	"io"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+)
+
+// DumpCacheKeys writes keys of the cached receipts, txs positions and EVM blocks, to warm the caches up after restart.
+func (s *Store) DumpCacheKeys(w io.Writer) error {
+	return s.evm.DumpCacheKeys(w)
+}
+
+// WarmCachesFromKeys loads the entries with keys written by DumpCacheKeys into the caches.
+// It's best-effort, keys which aren't in DB anymore (e.g. after pruning) are skipped.
+func (s *Store) WarmCachesFromKeys(r io.Reader) error {
+	reader := &EvmStateReader{store: s}
+	return s.evm.WarmCachesFromKeys(r, func(n idx.Block) *evmcore.EvmBlock {
+		return reader.getBlock(hash.Event{}, n, true)
+	})
+}
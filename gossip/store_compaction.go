@@ -0,0 +1,97 @@
+package gossip
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+)
+
+// deletionsCounter is a DB wrapper which counts total size of deleted keys.
+// Sizes of deleted values are unknown without reading them, so they aren't counted.
+type deletionsCounter struct {
+	kvdb.Store
+	deletedKeysSize *uint64
+}
+
+type batchDeletionsCounter struct {
+	kvdb.Batch
+	deletedKeysSize *uint64
+}
+
+func (db *deletionsCounter) Delete(key []byte) error {
+	atomic.AddUint64(db.deletedKeysSize, uint64(len(key)))
+	return db.Store.Delete(key)
+}
+
+func (db *deletionsCounter) NewBatch() kvdb.Batch {
+	return &batchDeletionsCounter{
+		Batch:           db.Store.NewBatch(),
+		deletedKeysSize: db.deletedKeysSize,
+	}
+}
+
+func (b *batchDeletionsCounter) Delete(key []byte) error {
+	atomic.AddUint64(b.deletedKeysSize, uint64(len(key)))
+	return b.Batch.Delete(key)
+}
+
+// compactor compacts main DB in background once enough data was deleted.
+type compactor struct {
+	deletedKeysSize uint64 // total size of keys deleted since the last compaction
+
+	// mu prevents compaction during Commit
+	mu sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// startCompaction launches the background compaction of main DB if it's enabled.
+func (s *Store) startCompaction() {
+	s.compactor.quit = make(chan struct{})
+	if s.cfg.Compaction.Period == 0 {
+		return
+	}
+	s.compactor.wg.Add(1)
+	go func() {
+		defer s.compactor.wg.Done()
+		ticker := time.NewTicker(s.cfg.Compaction.Period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.compactIfNeeded()
+			case <-s.compactor.quit:
+				return
+			}
+		}
+	}()
+}
+
+// stopCompaction stops the background compaction and waits for it to finish.
+func (s *Store) stopCompaction() {
+	close(s.compactor.quit)
+	s.compactor.wg.Wait()
+}
+
+// compactIfNeeded compacts the configured key range of main DB if total size of deleted keys exceeds the threshold.
+// Returns true if compaction was performed.
+func (s *Store) compactIfNeeded() bool {
+	if atomic.LoadUint64(&s.compactor.deletedKeysSize) < s.cfg.Compaction.DeletedKeysSizeThreshold {
+		return false
+	}
+	s.compactor.mu.Lock()
+	defer s.compactor.mu.Unlock()
+
+	deletedKeysSize := atomic.SwapUint64(&s.compactor.deletedKeysSize, 0)
+	start := time.Now()
+	err := s.mainDB.Compact(s.cfg.Compaction.Start, s.cfg.Compaction.Limit)
+	if err != nil {
+		s.Log.Error("Failed to compact DB", "err", err)
+		return false
+	}
+	s.Log.Info("Compacted DB", "deletedKeysSize", deletedKeysSize, "elapsed", time.Since(start))
+	return true
+}
@@ -0,0 +1,116 @@
+package gossip
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+// compactionsCounter is a DB wrapper which counts Compact calls.
+// Memory DB doesn't support compaction, so the call isn't forwarded.
+type compactionsCounter struct {
+	kvdb.DropableStore
+	compactions *uint32
+}
+
+func (db *compactionsCounter) Compact(start []byte, limit []byte) error {
+	atomic.AddUint32(db.compactions, 1)
+	return nil
+}
+
+func TestStoreCompaction(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	var compactions uint32
+	mems := memorydb.NewProducer("", func(db kvdb.DropableStore) kvdb.DropableStore {
+		return &compactionsCounter{
+			DropableStore: db,
+			compactions:   &compactions,
+		}
+	})
+	cfg := LiteStoreConfig()
+	cfg.Compaction = CompactionConfig{
+		Period:                   time.Millisecond,
+		DeletedKeysSizeThreshold: 100,
+	}
+	store := NewStore(flushable.NewSyncedPool(mems, []byte{0}), cfg)
+	defer store.Close()
+	// underlying DB is opened lazily on the first flush
+	require.NoError(store.dbs.Flush(bigendian.Uint64ToBytes(1)))
+
+	key := make([]byte, 10)
+	for i := 0; i < 9; i++ {
+		require.NoError(store.table.Blocks.Delete(key))
+	}
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(uint32(0), atomic.LoadUint32(&compactions))
+
+	batch := store.table.Blocks.NewBatch()
+	require.NoError(batch.Delete(key))
+	require.NoError(batch.Write())
+	require.Eventually(func() bool {
+		return atomic.LoadUint32(&compactions) == 1
+	}, time.Second, time.Millisecond)
+	require.Equal(uint64(0), atomic.LoadUint64(&store.compactor.deletedKeysSize))
+}
+
+// blockingCompactor is a DB wrapper which blocks Compact calls until released.
+type blockingCompactor struct {
+	kvdb.Store
+	started chan struct{}
+	release chan struct{}
+}
+
+func (db *blockingCompactor) Compact(start []byte, limit []byte) error {
+	close(db.started)
+	<-db.release
+	return nil
+}
+
+func TestStoreCommitDuringCompaction(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+	store := env.store
+	db := &blockingCompactor{
+		Store:   store.mainDB,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	store.mainDB = db
+	defer func() {
+		store.mainDB = db.Store
+	}()
+
+	atomic.StoreUint64(&store.compactor.deletedKeysSize, store.cfg.Compaction.DeletedKeysSizeThreshold)
+	compacted := make(chan bool)
+	go func() {
+		compacted <- store.compactIfNeeded()
+	}()
+	<-db.started
+
+	committed := make(chan error)
+	go func() {
+		committed <- store.Commit()
+	}()
+	// Commit waits for the compaction
+	select {
+	case <-committed:
+		require.Fail("Commit didn't wait for the compaction")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(db.release)
+	require.True(<-compacted)
+	require.NoError(<-committed)
+}
@@ -80,6 +80,12 @@ func (s *Store) GetRules() opera.Rules {
 	return s.GetEpochState().Rules
 }
 
+// GetEconomyParams retrieves a copy of the current network economy rules, which is safe to modify.
+// Note: rewards are computed by the SFC contract, so reward parameters aren't a part of the rules.
+func (s *Store) GetEconomyParams() opera.EconomyRules {
+	return s.GetRules().Economy.Copy()
+}
+
 // GetEpochRules retrieves current network rules and epoch atomically
 func (s *Store) GetEpochRules() (opera.Rules, idx.Epoch) {
 	es := s.GetEpochState()
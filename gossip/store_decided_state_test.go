@@ -0,0 +1,28 @@
+package gossip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetEconomyParams(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	expected := env.store.GetRules().Economy
+	got := env.store.GetEconomyParams()
+	require.Equal(expected, got)
+
+	// the returned values are copies
+	got.MinGasPrice.Add(got.MinGasPrice, big.NewInt(1))
+	got.BlockMissedSlack++
+	require.Equal(expected, env.store.GetEconomyParams())
+	require.NotEqual(expected.MinGasPrice, got.MinGasPrice)
+}
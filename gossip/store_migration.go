@@ -37,7 +37,8 @@ func (s *Store) migrations() *migration.Migration {
 		Next("used gas recovery", s.recoverUsedGas).
 		Next("tx hashes recovery", s.recoverTxHashes).
 		Next("DAG heads recovery", s.recoverHeadsStorage).
-		Next("DAG last events recovery", s.recoverLastEventsStorage)
+		Next("DAG last events recovery", s.recoverLastEventsStorage).
+		Next("SFC stakers address index", s.recoverStakersByAddressIndex)
 }
 
 func (s *Store) recoverUsedGas() error {
@@ -251,3 +252,8 @@ func (s *Store) recoverLastEventsStorage() error {
 	es.FlushLastEvents()
 	return nil
 }
+
+func (s *Store) recoverStakersByAddressIndex() error {
+	s.sfcapi.RebuildStakersByAddressIndex()
+	return nil
+}
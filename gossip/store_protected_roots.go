@@ -0,0 +1,32 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/hash"
+)
+
+// ProtectedRoots returns the EVM state roots which are referenced by the node, so an external state pruner must retain them:
+// the genesis state, the state of the current epoch start, the head state, and the states of the EVM snapshot layers.
+// Roots are unique, the head root is the first one. No state is modified.
+func (s *Store) ProtectedRoots() []hash.Hash {
+	bs, es := s.GetBlockEpochState()
+
+	roots := make([]hash.Hash, 0, 4)
+	seen := make(map[hash.Hash]bool)
+	add := func(root hash.Hash) {
+		if root == hash.Zero || seen[root] {
+			return
+		}
+		seen[root] = true
+		roots = append(roots, root)
+	}
+
+	add(bs.FinalizedStateRoot)
+	for _, root := range s.evm.SnapshotRoots(bs.FinalizedStateRoot) {
+		add(root)
+	}
+	add(es.EpochStateRoot)
+	if genesis, ok := s.GenesisRoot(); ok {
+		add(genesis)
+	}
+	return roots
+}
@@ -0,0 +1,38 @@
+package gossip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreProtectedRoots(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	genesisRoot := env.store.GetBlock(*env.store.GetGenesisBlockIndex()).Root
+	require.Contains(env.store.ProtectedRoots(), genesisRoot)
+
+	env.ApplyBlock(nextEpoch, env.Transfer(1, 2, big.NewInt(1)))
+	env.ApplyBlock(sameEpoch, env.Transfer(2, 1, big.NewInt(1)))
+
+	bs, es := env.store.GetBlockEpochState()
+	require.NotEqual(genesisRoot, bs.FinalizedStateRoot)
+	roots := env.store.ProtectedRoots()
+	require.Equal(bs.FinalizedStateRoot, roots[0])
+	require.Contains(roots, es.EpochStateRoot)
+	require.Contains(roots, genesisRoot)
+
+	// roots are unique
+	seen := make(map[interface{}]bool)
+	for _, root := range roots {
+		require.False(seen[root])
+		seen[root] = true
+	}
+}
@@ -0,0 +1,43 @@
+package gossip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrReceiptsCorrupted is returned if stored receipts are inconsistent
+	ErrReceiptsCorrupted = errors.New("receipts are corrupted")
+)
+
+// GetReceiptsChecked returns stored receipts of the block, checking that their CumulativeGasUsed
+// isn't decreasing and that the last one matches the gas used by the block.
+// It's a lightweight integrity check, the receipts aren't re-executed.
+func (s *Store) GetReceiptsChecked(n idx.Block) (types.Receipts, error) {
+	block := s.GetBlock(n)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", n)
+	}
+	receipts := s.evm.GetReceipts(n)
+	if err := checkReceiptsGas(receipts, block.GasUsed); err != nil {
+		return nil, fmt.Errorf("block %d: %w", n, err)
+	}
+	return receipts, nil
+}
+
+func checkReceiptsGas(receipts types.Receipts, gasUsed uint64) error {
+	var prev uint64
+	for i, r := range receipts {
+		if r.CumulativeGasUsed < prev {
+			return fmt.Errorf("%w: tx %d cumulative gas used %d is lower than %d", ErrReceiptsCorrupted, i, r.CumulativeGasUsed, prev)
+		}
+		prev = r.CumulativeGasUsed
+	}
+	if prev != gasUsed {
+		return fmt.Errorf("%w: cumulative gas used %d != block gas used %d", ErrReceiptsCorrupted, prev, gasUsed)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package gossip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetReceiptsChecked(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := NewMemStore()
+	defer store.Close()
+
+	receiptsOf := func(cumulative ...uint64) types.Receipts {
+		receipts := make(types.Receipts, len(cumulative))
+		for i, c := range cumulative {
+			receipts[i] = &types.Receipt{
+				Status:            types.ReceiptStatusSuccessful,
+				CumulativeGasUsed: c,
+				Logs:              []*types.Log{},
+			}
+		}
+		return receipts
+	}
+
+	// valid
+	store.SetBlock(1, &inter.Block{GasUsed: 300})
+	store.EvmStore().SetReceipts(1, receiptsOf(100, 100, 300))
+	receipts, err := store.GetReceiptsChecked(1)
+	require.NoError(err)
+	require.Len(receipts, 3)
+
+	// empty block
+	store.SetBlock(2, &inter.Block{})
+	receipts, err = store.GetReceiptsChecked(2)
+	require.NoError(err)
+	require.Empty(receipts)
+
+	// tampered: decreasing cumulative gas
+	store.SetBlock(3, &inter.Block{GasUsed: 300})
+	store.EvmStore().SetReceipts(3, receiptsOf(100, 50, 300))
+	_, err = store.GetReceiptsChecked(3)
+	require.True(errors.Is(err, ErrReceiptsCorrupted), err)
+
+	// tampered: last doesn't match the block
+	store.SetBlock(4, &inter.Block{GasUsed: 300})
+	store.EvmStore().SetReceipts(4, receiptsOf(100, 200))
+	_, err = store.GetReceiptsChecked(4)
+	require.True(errors.Is(err, ErrReceiptsCorrupted), err)
+
+	// missing block
+	_, err = store.GetReceiptsChecked(5)
+	require.Error(err)
+}
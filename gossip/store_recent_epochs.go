@@ -0,0 +1,53 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// EpochSummary is a summary of a sealed epoch
+type EpochSummary struct {
+	Epoch    idx.Epoch
+	Start    inter.Timestamp
+	End      inter.Timestamp
+	Duration inter.Timestamp
+	// FirstBlock and LastBlock are the first block of the epoch and the block which sealed it
+	FirstBlock idx.Block
+	LastBlock  idx.Block
+}
+
+// GetRecentEpochs returns summaries of up to n latest sealed epochs, from the latest one.
+// Fewer summaries are returned if the sealing blocks of the older epochs aren't indexed.
+// Note: fees, rewards and validators of the past epochs aren't indexed by the node.
+func (s *Store) GetRecentEpochs(n int) []EpochSummary {
+	if n <= 0 {
+		return []EpochSummary{}
+	}
+	res := make([]EpochSummary, 0, n)
+	epoch := s.GetEpoch() - 1
+	lastBlock, ok := s.evm.GetEpochSealBlock(epoch)
+	if !ok {
+		return res
+	}
+	for ; len(res) < n && epoch > 0; epoch-- {
+		prevBlock, prevOk := s.evm.GetEpochSealBlock(epoch - 1)
+		if !prevOk {
+			break
+		}
+		last, prev := s.GetBlock(idx.Block(lastBlock)), s.GetBlock(idx.Block(prevBlock))
+		if last == nil || prev == nil {
+			break
+		}
+		res = append(res, EpochSummary{
+			Epoch:      epoch,
+			Start:      prev.Time,
+			End:        last.Time,
+			Duration:   last.Time - prev.Time,
+			FirstBlock: idx.Block(prevBlock) + 1,
+			LastBlock:  idx.Block(lastBlock),
+		})
+		lastBlock = prevBlock
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStoreGetRecentEpochs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	startEpoch := env.store.GetEpoch()
+	// only the genesis epoch is sealed, so its start is unknown
+	require.Empty(env.store.GetRecentEpochs(5))
+
+	const epochs = 3
+	for i := 0; i < epochs; i++ {
+		env.ApplyBlock(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+		env.ApplyBlock(sameEpoch)
+		env.ApplyBlock(nextEpoch)
+	}
+	require.Equal(startEpoch+epochs, env.store.GetEpoch())
+
+	got := env.store.GetRecentEpochs(5)
+	require.Len(got, epochs)
+	for i, summary := range got {
+		require.Equal(startEpoch+epochs-1-idx.Epoch(i), summary.Epoch)
+		sealBlock, ok := env.store.evm.GetEpochSealBlock(summary.Epoch)
+		require.True(ok)
+		prevSealBlock, ok := env.store.evm.GetEpochSealBlock(summary.Epoch - 1)
+		require.True(ok)
+		require.Equal(idx.Block(sealBlock), summary.LastBlock)
+		require.Equal(idx.Block(prevSealBlock)+1, summary.FirstBlock)
+		require.Equal(idx.Block(3), summary.LastBlock-summary.FirstBlock+1)
+		require.Equal(env.store.GetBlock(idx.Block(prevSealBlock)).Time, summary.Start)
+		require.Equal(env.store.GetBlock(idx.Block(sealBlock)).Time, summary.End)
+		require.Equal(summary.End-summary.Start, summary.Duration)
+		require.Less(uint64(0), uint64(summary.Duration))
+	}
+
+	require.Equal(got[:2], env.store.GetRecentEpochs(2))
+	require.Empty(env.store.GetRecentEpochs(0))
+	require.Empty(env.store.GetRecentEpochs(-1))
+}
@@ -0,0 +1,58 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc/drivermodule"
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc/sealmodule"
+	"github.com/Fantom-foundation/go-opera/opera/genesis/driver/drivercall"
+)
+
+// EpochSealSimulation is a projection of the current epoch sealing
+type EpochSealSimulation struct {
+	Epoch idx.Epoch
+	// Metrics are the validators metrics which would be passed into Driver
+	Metrics map[idx.ValidatorID]drivercall.ValidatorEpochMetric
+	// NextValidators is the projected validators group of the next epoch
+	NextValidators *pos.Validators
+}
+
+// SimulateEpochSeal calculates what would be written on the current epoch sealing,
+// as if the epoch was sealed at the last block. No state is modified.
+func (s *Store) SimulateEpochSeal() EpochSealSimulation {
+	// Note: loads bs and es atomically to avoid a race condition
+	bs, es := s.GetBlockEpochState()
+
+	metrics := drivermodule.SealEpochMetrics(bs.LastBlock, bs, es)
+	res := EpochSealSimulation{
+		Epoch:          es.Epoch,
+		Metrics:        make(map[idx.ValidatorID]drivercall.ValidatorEpochMetric, len(metrics)),
+		NextValidators: projectNextValidators(bs, es),
+	}
+	for i, m := range metrics {
+		res.Metrics[es.Validators.GetID(idx.Validator(i))] = m
+	}
+	return res
+}
+
+// ProjectedNextValidatorCount returns the number of validators of the next epoch,
+// as if the epoch was sealed at the last block. No state is modified.
+func (s *Store) ProjectedNextValidatorCount() int {
+	bs, es := s.GetBlockEpochState()
+	return int(projectNextValidators(bs, es).Len())
+}
+
+// projectNextValidators builds validators of the next epoch the same way as the sealer does.
+// Detected cheaters are excluded, because they're deactivated in Driver before the epoch sealing.
+func projectNextValidators(bs blockproc.BlockState, es blockproc.EpochState) *pos.Validators {
+	cheaters := epochCheaters(BlockEpochState{&bs, &es})
+	if len(cheaters) != 0 {
+		bs.NextValidatorProfiles = bs.NextValidatorProfiles.Copy()
+		for id := range cheaters {
+			delete(bs.NextValidatorProfiles, id)
+		}
+	}
+	return sealmodule.NextValidators(bs)
+}
@@ -0,0 +1,68 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/lachesis"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc/sealmodule"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreSimulateEpochSeal(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	sim := env.store.SimulateEpochSeal()
+
+	bs, es := env.store.GetBlockEpochState()
+	require.Equal(es.Epoch, sim.Epoch)
+	require.Equal(int(es.Validators.Len()), len(sim.Metrics))
+	for _, id := range es.Validators.IDs() {
+		_, ok := sim.Metrics[id]
+		require.True(ok)
+	}
+
+	// simulation doesn't modify the state
+	bsAfter, esAfter := env.store.GetBlockEpochState()
+	require.Equal(bs.LastBlock, bsAfter.LastBlock)
+	require.Equal(es.Epoch, esAfter.Epoch)
+
+	// compare to the actual sealing
+	sealer := sealmodule.New().Start(bs.LastBlock, bs.Copy(), es.Copy())
+	_, sealedEs := sealer.SealEpoch()
+	require.Equal(sealedEs.Validators.SortedIDs(), sim.NextValidators.SortedIDs())
+	for _, id := range sealedEs.Validators.IDs() {
+		require.Equal(sealedEs.Validators.Get(id), sim.NextValidators.Get(id))
+	}
+}
+
+func TestStoreProjectedNextValidatorCount(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	bs, es := env.store.GetBlockEpochState()
+	require.Equal(int(es.Validators.Len()), env.store.ProjectedNextValidatorCount())
+
+	// a cheater is detected
+	cheater := es.Validators.GetID(0)
+	bs = bs.Copy()
+	bs.EpochCheaters = lachesis.Cheaters{cheater}
+	env.store.SetBlockEpochState(bs, es)
+	projected := env.store.ProjectedNextValidatorCount()
+	require.Equal(int(es.Validators.Len())-1, projected)
+
+	// compare to the actual sealing, the cheater is deactivated by Driver before it
+	delete(bs.NextValidatorProfiles, cheater)
+	sealer := sealmodule.New().Start(bs.LastBlock, bs, es.Copy())
+	_, sealedEs := sealer.SealEpoch()
+	require.Equal(int(sealedEs.Validators.Len()), projected)
+	require.False(sealedEs.Validators.Exists(cheater))
+}
@@ -0,0 +1,161 @@
+package gossip
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/integration/makegenesis"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStoreIsCommitNeededByBlocks(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+	store := env.store
+	store.cfg.MaxNonFlushedBlocks = 3
+
+	bs, es := store.GetBlockEpochState()
+	setBlock := func(n idx.Block) {
+		bs.LastBlock.Idx = n
+		store.SetBlockEpochState(bs, es)
+	}
+
+	setBlock(1)
+	require.NoError(store.Commit())
+	for n := idx.Block(2); n <= 10; n++ {
+		setBlock(n)
+		// the DB is flushed every 3 blocks
+		flush := (n-1)%3 == 0
+		require.Equal(flush, store.IsCommitNeeded(false), n)
+		if flush {
+			require.NoError(store.Commit())
+			require.False(store.IsCommitNeeded(false))
+		}
+	}
+}
+
+func TestStoreJournal(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	mems := memorydb.NewProducer("")
+	cfg := LiteStoreConfig()
+	cfg.EVM.EnableSnapshots = true
+	cfg.EVM.Cache.EvmSnap = 1024 * 1024
+
+	genStore := makegenesis.FakeGenesisStore(genesisStakers, utils.ToFtm(genesisBalance), utils.ToFtm(genesisStake))
+	store := NewStore(flushable.NewSyncedPool(mems, []byte{0}), cfg)
+	_, err := store.ApplyGenesis(DefaultBlockProc(genStore.GetGenesis()), genStore.GetGenesis())
+	require.NoError(err)
+	require.NoError(store.Init())
+	require.Empty(rawdb.ReadSnapshotJournal(store.EvmStore().EvmTable()))
+	reopen := func() *Store {
+		store.Close()
+		dbs := flushable.NewSyncedPool(mems, []byte{0})
+		require.NoError(dbs.Initialize(mems.Names()))
+		store = NewStore(dbs, cfg)
+		return store
+	}
+
+	// Close doesn't write the snapshot journal
+	reopen()
+	require.Empty(rawdb.ReadSnapshotJournal(store.EvmStore().EvmTable()))
+	require.NoError(store.Init())
+
+	// the snapshot journal is kept if it's committed, as on the Service stop
+	require.NoError(store.EvmStore().Journal())
+	require.NoError(store.Commit())
+	defer reopen().Close()
+	require.NotEmpty(rawdb.ReadSnapshotJournal(store.EvmStore().EvmTable()))
+}
+
+func TestStoreCommitPausedLogs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+	store := env.store
+
+	l := &types.Log{
+		Address:     common.Address{1},
+		Topics:      []common.Hash{{2}},
+		Data:        []byte{},
+		BlockNumber: 100,
+	}
+	pattern := [][]common.Hash{{l.Address.Hash()}}
+	store.evm.PauseLogIndexing()
+	store.evm.IndexLogs(l)
+	got, err := store.evm.FindLogsInBlocks(context.Background(), 100, 100, pattern)
+	require.NoError(err)
+	require.Empty(got)
+
+	// the buffered logs are flushed along with the DB
+	require.NoError(store.Commit())
+	got, err = store.evm.FindLogsInBlocks(context.Background(), 100, 100, pattern)
+	require.NoError(err)
+	require.Equal([]*types.Log{l}, got)
+	require.NoError(store.evm.ResumeLogIndexing())
+}
+
+func TestStoreConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]string{"_", "D", "e", "b", "g", "l", "V", "B", "S", "k"}, mainDBTables())
+
+	cfg := LiteStoreConfig()
+	require.NoError(cfg.Validate())
+	// EVM tables mustn't overlap with the main DB tables
+	cfg.EVM.Tables.Receipts = "e"
+	require.Error(cfg.Validate())
+	cfg.EVM.Tables.Receipts = "Sr"
+	require.Error(cfg.Validate())
+}
+
+func TestStoreTrieCleanJournalNetwork(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	dir := t.TempDir()
+	mems := memorydb.NewProducer("")
+	cfg := LiteStoreConfig()
+	cfg.EVM.Cache.EvmDatabase = 1024 * 1024
+	cfg.EVM.TrieCleanJournal = dir
+
+	// the network is unknown before genesis, so the journal isn't used
+	genStore := makegenesis.FakeGenesisStore(genesisStakers, utils.ToFtm(genesisBalance), utils.ToFtm(genesisStake))
+	store := NewStore(flushable.NewSyncedPool(mems, []byte{0}), cfg)
+	_, err := store.ApplyGenesis(DefaultBlockProc(genStore.GetGenesis()), genStore.GetGenesis())
+	require.NoError(err)
+	require.NoError(store.Commit())
+	store.Close()
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(err)
+	require.Empty(files)
+
+	// the journal directory is namespaced by the network ID
+	dbs := flushable.NewSyncedPool(mems, []byte{0})
+	require.NoError(dbs.Initialize(mems.Names()))
+	store = NewStore(dbs, cfg)
+	require.NoError(store.EvmStore().Journal())
+	networkDir := filepath.Join(dir, strconv.FormatUint(store.GetRules().NetworkID, 10))
+	store.Close()
+	files, err = ioutil.ReadDir(networkDir)
+	require.NoError(err)
+	require.NotEmpty(files)
+}
@@ -0,0 +1,62 @@
+package gossip
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
+)
+
+// GetTransactionSender returns the sender of an indexed transaction. The sender is recovered from the signature once,
+// and then is cached by the transaction hash. Blocks are final, so a cached sender never gets stale.
+func (s *Store) GetTransactionSender(txid common.Hash, signer types.Signer) (common.Address, error) {
+	// signers wrapped with a senders cache aren't equal to the unwrapped ones
+	if cs, ok := signer.(*types.CachedSigner); ok {
+		signer = cs.Signer
+	}
+	if c, ok := s.cache.TxSenders.Get(txid); ok {
+		if cached := c.(types.CachedSender); cached.Signer.Equal(signer) {
+			return cached.From, nil
+		}
+	}
+
+	position := s.evm.GetTxPosition(txid)
+	if position == nil {
+		return common.Address{}, ErrTxNotFound
+	}
+	tx, err := s.getPositionedTx(txid, position)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if tx == nil {
+		return common.Address{}, ErrTxNotFound
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	s.cache.TxSenders.Add(txid, types.CachedSender{From: from, Signer: signer}, nominalSize)
+	return from, nil
+}
+
+// getPositionedTx returns the transaction at the indexed position, i.e. either from the event or from the non-event transactions.
+func (s *Store) getPositionedTx(txid common.Hash, position *evmstore.TxPosition) (*types.Transaction, error) {
+	if position.Event.IsZero() {
+		return s.evm.GetTx(txid), nil
+	}
+	event := s.GetEventPayload(position.Event)
+	if event == nil {
+		return nil, nil
+	}
+	if position.EventOffset >= uint32(event.Txs().Len()) {
+		return nil, fmt.Errorf("transactions index is corrupted (offset is larger than number of txs in event), event=%s, txid=%s, block=%d, offset=%d, txs_num=%d",
+			position.Event.String(),
+			txid.String(),
+			position.Block,
+			position.EventOffset,
+			event.Txs().Len())
+	}
+	return event.Txs()[position.EventOffset], nil
+}
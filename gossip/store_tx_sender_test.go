@@ -0,0 +1,96 @@
+package gossip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils/gsignercache"
+)
+
+// fakeTxSenderStore returns a store with indexed event and non-event transactions signed by the key
+func fakeTxSenderStore(t testing.TB, signer types.Signer) (store *Store, eventTx, tx *types.Transaction, from common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from = crypto.PubkeyToAddress(key.PublicKey)
+	eventTx, err = types.SignTx(types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+	require.NoError(t, err)
+	tx, err = types.SignTx(types.NewTransaction(1, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+	require.NoError(t, err)
+
+	store = NewMemStore()
+	e := inter.MutableEventPayload{}
+	e.SetTxs(types.Transactions{eventTx})
+	event := e.Build()
+	store.SetEvent(event)
+	store.evm.SetTxPosition(eventTx.Hash(), evmstore.TxPosition{Block: 1, Event: event.ID()})
+	store.evm.SetTx(tx.Hash(), tx)
+	store.evm.SetTxPosition(tx.Hash(), evmstore.TxPosition{Block: 1, BlockOffset: 1})
+	return
+}
+
+func TestStoreGetTransactionSender(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	signer := types.NewEIP2930Signer(big.NewInt(1))
+	store, eventTx, tx, from := fakeTxSenderStore(t, signer)
+	defer store.Close()
+
+	_, err := store.GetTransactionSender(common.Hash{1}, signer)
+	require.ErrorIs(err, ErrTxNotFound)
+
+	for _, txid := range []common.Hash{eventTx.Hash(), tx.Hash()} {
+		got, err := store.GetTransactionSender(txid, signer)
+		require.NoError(err)
+		require.Equal(from, got)
+		require.True(store.cache.TxSenders.Contains(txid))
+	}
+
+	// the cached sender is reused, the tx isn't read again
+	position := *store.evm.GetTxPosition(eventTx.Hash())
+	position.EventOffset = 1000
+	store.evm.SetTxPosition(eventTx.Hash(), position)
+	got, err := store.GetTransactionSender(eventTx.Hash(), signer)
+	require.NoError(err)
+	require.Equal(from, got)
+	// including a signer wrapped with the senders cache
+	got, err = store.GetTransactionSender(eventTx.Hash(), gsignercache.Wrap(signer))
+	require.NoError(err)
+	require.Equal(from, got)
+
+	// the sender is recovered again for another signer
+	_, err = store.GetTransactionSender(eventTx.Hash(), types.NewEIP2930Signer(big.NewInt(2)))
+	require.Error(err)
+	require.NotErrorIs(err, ErrTxNotFound)
+}
+
+func BenchmarkStoreGetTransactionSender(b *testing.B) {
+	logger.SetTestMode(b)
+	require := require.New(b)
+
+	signer := types.NewEIP2930Signer(big.NewInt(1))
+	store, eventTx, _, _ := fakeTxSenderStore(b, signer)
+	defer store.Close()
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := store.GetTransactionSender(eventTx.Hash(), signer)
+			require.NoError(err)
+		}
+	})
+	b.Run("recovered", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			store.cache.TxSenders.Purge()
+			_, err := store.GetTransactionSender(eventTx.Hash(), signer)
+			require.NoError(err)
+		}
+	})
+}
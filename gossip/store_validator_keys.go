@@ -0,0 +1,42 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+)
+
+// SetValidatorKeys indexes public keys of the validators. Previous keys of the validators are retained.
+func (s *Store) SetValidatorKeys(profiles blockproc.ValidatorProfiles) {
+	for id, profile := range profiles {
+		if profile.PubKey.Empty() {
+			continue
+		}
+		if err := s.table.ValidatorKeys.Put(profile.PubKey.Bytes(), id.Bytes()); err != nil {
+			s.Log.Crit("Failed to put key-value", "err", err)
+		}
+	}
+}
+
+// GetStakerIDByValidatorKey returns ID of the validator which used the public key to sign events.
+// Keys are indexed on epoch sealing, so keys of the current epoch validators are also looked up in the epoch state.
+func (s *Store) GetStakerIDByValidatorKey(pubkey validatorpk.PubKey) (idx.ValidatorID, bool) {
+	if pubkey.Empty() {
+		return 0, false
+	}
+	buf, err := s.table.ValidatorKeys.Get(pubkey.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if buf != nil {
+		return idx.BytesToValidatorID(buf), true
+	}
+	// the epoch was sealed before the index was introduced
+	for id, profile := range s.GetEpochState().ValidatorProfiles {
+		if profile.PubKey.Type == pubkey.Type && string(profile.PubKey.Raw) == string(pubkey.Raw) {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,46 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/inter/drivertype"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestStoreGetStakerIDByValidatorKey(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	// genesis validators are indexed
+	es := env.store.GetEpochState()
+	for id, profile := range es.ValidatorProfiles {
+		got, ok := env.store.GetStakerIDByValidatorKey(profile.PubKey)
+		require.True(ok)
+		require.Equal(id, got)
+	}
+
+	// round-trip, a previous key of a validator is retained
+	key1 := validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{1, 2, 3}}
+	key2 := validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{4, 5, 6}}
+	env.store.SetValidatorKeys(blockproc.ValidatorProfiles{100: drivertype.Validator{PubKey: key1}})
+	env.store.SetValidatorKeys(blockproc.ValidatorProfiles{100: drivertype.Validator{PubKey: key2}})
+	for _, key := range []validatorpk.PubKey{key1, key2} {
+		got, ok := env.store.GetStakerIDByValidatorKey(key)
+		require.True(ok)
+		require.Equal(idx.ValidatorID(100), got)
+	}
+
+	// missing keys
+	_, ok := env.store.GetStakerIDByValidatorKey(validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{7}})
+	require.False(ok)
+	_, ok = env.store.GetStakerIDByValidatorKey(validatorpk.PubKey{})
+	require.False(ok)
+}
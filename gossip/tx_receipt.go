@@ -0,0 +1,79 @@
+package gossip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
+)
+
+var (
+	// ErrTxNotFound is returned if requested transaction isn't indexed
+	ErrTxNotFound = errors.New("transaction not found")
+)
+
+// GetTransactionReceipt returns the receipt of an indexed transaction along with its position.
+// The derived fields of the receipt and its logs (block hash and number, tx hash and index) are populated.
+// Returns nil receipt and position if the transaction isn't indexed.
+func (r *EvmStateReader) GetTransactionReceipt(txHash common.Hash) (*types.Receipt, *evmstore.TxPosition, error) {
+	position := r.store.evm.GetTxPosition(txHash)
+	if position == nil {
+		return nil, nil, nil
+	}
+	block := r.GetBlock(common.Hash{}, uint64(position.Block))
+	if block == nil {
+		return nil, nil, fmt.Errorf("block %d not found", position.Block)
+	}
+	receipts := r.store.evm.GetReceipts(position.Block)
+	if int(position.BlockOffset) >= len(receipts) || len(receipts) != len(block.Transactions) {
+		return nil, nil, fmt.Errorf("transactions index is corrupted (offset is larger than number of receipts), txid=%s, block=%d, offset=%d, receipts_num=%d, txs_num=%d",
+			txHash.String(),
+			position.Block,
+			position.BlockOffset,
+			len(receipts),
+			len(block.Transactions))
+	}
+
+	derived, err := r.deriveReceipts(block, receipts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return derived[position.BlockOffset], position, nil
+}
+
+// deriveReceipts returns copies of the block receipts with the derived fields populated.
+// Receipts may be shared with the cache, so they aren't modified.
+func (r *EvmStateReader) deriveReceipts(block *evmcore.EvmBlock, receipts types.Receipts) (types.Receipts, error) {
+	derived := make(types.Receipts, len(receipts))
+	for i, receipt := range receipts {
+		cp := *receipt
+		cp.Logs = make([]*types.Log, len(receipt.Logs))
+		for j, l := range receipt.Logs {
+			lcp := *l
+			cp.Logs[j] = &lcp
+		}
+		derived[i] = &cp
+	}
+	err := derived.DeriveFields(r.Config(), common.Hash(block.Hash), block.Number.Uint64(), block.Transactions)
+	return derived, err
+}
+
+// GetTransactionLogs returns logs of an indexed transaction, with the log indexes relative to the block.
+// Returns an empty slice if the transaction emitted no logs, and ErrTxNotFound if it isn't indexed.
+func (r *EvmStateReader) GetTransactionLogs(txHash common.Hash) ([]*types.Log, error) {
+	receipt, _, err := r.GetTransactionReceipt(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, ErrTxNotFound
+	}
+	if receipt.Logs == nil {
+		return []*types.Log{}, nil
+	}
+	return receipt.Logs, nil
+}
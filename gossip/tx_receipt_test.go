@@ -0,0 +1,112 @@
+package gossip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/evmstore"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestGetTransactionReceipt(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	n := env.store.GetLatestBlockIndex() + 1
+	tx1 := types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(0), nil)
+	tx2 := types.NewTransaction(1, common.Address{2}, big.NewInt(1), 30000, big.NewInt(0), nil)
+	env.store.evm.SetTx(tx1.Hash(), tx1)
+	env.store.evm.SetTx(tx2.Hash(), tx2)
+	env.store.SetBlock(n, &inter.Block{
+		Atropos:     hash.FakeEvent(),
+		InternalTxs: []common.Hash{tx1.Hash(), tx2.Hash()},
+	})
+	env.store.evm.SetTxPosition(tx1.Hash(), evmstore.TxPosition{Block: n, BlockOffset: 0})
+	env.store.evm.SetTxPosition(tx2.Hash(), evmstore.TxPosition{Block: n, BlockOffset: 1})
+	env.store.evm.SetReceipts(n, types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{{Address: common.Address{1}}}},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 51000, Logs: []*types.Log{{Address: common.Address{2}}}},
+	})
+
+	receipt, position, err := env.stateReader.GetTransactionReceipt(tx2.Hash())
+	require.NoError(err)
+	require.NotNil(receipt)
+	require.Equal(n, position.Block)
+	require.Equal(uint32(1), position.BlockOffset)
+
+	block := env.stateReader.GetBlock(common.Hash{}, uint64(n))
+	require.Equal(tx2.Hash(), receipt.TxHash)
+	require.Equal(common.Hash(block.Hash), receipt.BlockHash)
+	require.Equal(uint64(n), receipt.BlockNumber.Uint64())
+	require.Equal(uint(1), receipt.TransactionIndex)
+	require.Equal(uint64(30000), receipt.GasUsed)
+	require.Len(receipt.Logs, 1)
+	require.Equal(tx2.Hash(), receipt.Logs[0].TxHash)
+	require.Equal(uint(1), receipt.Logs[0].Index)
+	require.Equal(uint(1), receipt.Logs[0].TxIndex)
+
+	// cached receipts aren't modified
+	require.Equal(common.Hash{}, env.store.evm.GetReceipts(n)[1].TxHash)
+
+	receipt, position, err = env.stateReader.GetTransactionReceipt(common.Hash{0xff})
+	require.NoError(err)
+	require.Nil(receipt)
+	require.Nil(position)
+}
+
+func TestGetTransactionLogs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	n := env.store.GetLatestBlockIndex() + 1
+	txs := types.Transactions{
+		types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(0), nil),
+		types.NewTransaction(1, common.Address{2}, big.NewInt(1), 21000, big.NewInt(0), nil),
+		types.NewTransaction(2, common.Address{3}, big.NewInt(1), 21000, big.NewInt(0), nil),
+	}
+	block := &inter.Block{Atropos: hash.FakeEvent()}
+	for i, tx := range txs {
+		env.store.evm.SetTx(tx.Hash(), tx)
+		env.store.evm.SetTxPosition(tx.Hash(), evmstore.TxPosition{Block: n, BlockOffset: uint32(i)})
+		block.InternalTxs = append(block.InternalTxs, tx.Hash())
+	}
+	env.store.SetBlock(n, block)
+	env.store.evm.SetReceipts(n, types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{{Address: common.Address{1}}}},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 42000},
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 63000, Logs: []*types.Log{{Address: common.Address{3}}, {Address: common.Address{4}}}},
+	})
+
+	// multiple logs
+	logs, err := env.stateReader.GetTransactionLogs(txs[2].Hash())
+	require.NoError(err)
+	require.Len(logs, 2)
+	for i, l := range logs {
+		require.Equal(uint(1+i), l.Index)
+		require.Equal(uint(2), l.TxIndex)
+		require.Equal(txs[2].Hash(), l.TxHash)
+		require.Equal(uint64(n), l.BlockNumber)
+	}
+	require.Equal(common.Address{4}, logs[1].Address)
+
+	// no logs
+	logs, err = env.stateReader.GetTransactionLogs(txs[1].Hash())
+	require.NoError(err)
+	require.NotNil(logs)
+	require.Empty(logs)
+
+	_, err = env.stateReader.GetTransactionLogs(common.Hash{0xff})
+	require.Equal(ErrTxNotFound, err)
+}
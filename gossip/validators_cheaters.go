@@ -0,0 +1,55 @@
+package gossip
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+)
+
+var (
+	// ErrNotCurrentEpoch is returned if the requested data is kept only for the current epoch
+	ErrNotCurrentEpoch = errors.New("not the current epoch")
+)
+
+// ForfeitedReward is a validator of the epoch, which is excluded from the epoch rewards as a cheater
+type ForfeitedReward struct {
+	ValidatorID idx.ValidatorID
+	// Weight is the validator weight, which the validator would have been rewarded for
+	Weight pos.Weight
+}
+
+// ExcludedCheaters returns validators of the epoch which are excluded from rewards as cheaters,
+// ordered by ID, and their total forfeited weight.
+// Cheaters are known only for the current epoch, ErrNotCurrentEpoch is returned for other epochs.
+func (s *Service) ExcludedCheaters(epoch idx.Epoch) ([]ForfeitedReward, pos.Weight, error) {
+	bes := s.store.getBlockEpochState()
+	if bes.EpochState.Epoch != epoch {
+		return nil, 0, ErrNotCurrentEpoch
+	}
+	forfeited, total := excludedCheaters(bes.EpochState.Validators, epochCheaters(bes))
+	return forfeited, total, nil
+}
+
+// excludedCheaters returns the validators which are cheaters, along with their weights.
+// Cheaters which aren't in the validators set are ignored.
+func excludedCheaters(validators *pos.Validators, cheaters map[idx.ValidatorID]bool) ([]ForfeitedReward, pos.Weight) {
+	forfeited := make([]ForfeitedReward, 0, len(cheaters))
+	total := pos.Weight(0)
+	for id := range cheaters {
+		if !validators.Exists(id) {
+			continue
+		}
+		w := validators.Get(id)
+		forfeited = append(forfeited, ForfeitedReward{
+			ValidatorID: id,
+			Weight:      w,
+		})
+		total += w
+	}
+	sort.Slice(forfeited, func(i, j int) bool {
+		return forfeited[i].ValidatorID < forfeited[j].ValidatorID
+	})
+	return forfeited, total
+}
@@ -0,0 +1,37 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludedCheaters(t *testing.T) {
+	require := require.New(t)
+
+	b := pos.NewBuilder()
+	b.Set(1, 10)
+	b.Set(2, 20)
+	b.Set(3, 30)
+	b.Set(4, 40)
+	validators := b.Build()
+
+	// no cheaters
+	forfeited, total := excludedCheaters(validators, map[idx.ValidatorID]bool{})
+	require.Empty(forfeited)
+	require.Equal(pos.Weight(0), total)
+
+	// mixed set, cheater 5 isn't a validator
+	forfeited, total = excludedCheaters(validators, map[idx.ValidatorID]bool{4: true, 2: true, 5: true})
+	require.Equal([]ForfeitedReward{
+		{ValidatorID: 2, Weight: 20},
+		{ValidatorID: 4, Weight: 40},
+	}, forfeited)
+	require.Equal(pos.Weight(60), total)
+
+	// all validators are cheaters
+	_, total = excludedCheaters(validators, map[idx.ValidatorID]bool{1: true, 2: true, 3: true, 4: true})
+	require.Equal(validators.TotalWeight(), total)
+}
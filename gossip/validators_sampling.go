@@ -0,0 +1,70 @@
+package gossip
+
+import (
+	"math/rand"
+
+	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+)
+
+// SampleValidators draws up to n distinct validators of the current epoch with probability proportional to their weights.
+// Validator weights are proportional to validators total stakes. Cheaters are excluded.
+// The result is deterministic for the same seed and validators set.
+func (s *Service) SampleValidators(n int, seed []byte) []idx.ValidatorID {
+	bes := s.store.getBlockEpochState()
+	return sampleValidators(bes.EpochState.Validators, epochCheaters(bes), n, seed)
+}
+
+// epochCheaters returns validators of the current epoch which are detected as cheaters
+func epochCheaters(bes BlockEpochState) map[idx.ValidatorID]bool {
+	validators := bes.EpochState.Validators
+	cheaters := make(map[idx.ValidatorID]bool, len(bes.BlockState.EpochCheaters))
+	for _, id := range bes.BlockState.EpochCheaters {
+		cheaters[id] = true
+	}
+	for i, info := range bes.BlockState.ValidatorStates {
+		if info.Cheater {
+			cheaters[validators.GetID(idx.Validator(i))] = true
+		}
+	}
+	return cheaters
+}
+
+// sampleValidators draws up to n distinct validators, which aren't excluded, with probability proportional to their weights.
+func sampleValidators(validators *pos.Validators, excluded map[idx.ValidatorID]bool, n int, seed []byte) []idx.ValidatorID {
+	ids := make([]idx.ValidatorID, 0, validators.Len())
+	weights := make([]uint64, 0, validators.Len())
+	total := uint64(0)
+	for _, id := range validators.SortedIDs() {
+		w := uint64(validators.Get(id))
+		if excluded[id] || w == 0 {
+			continue
+		}
+		ids = append(ids, id)
+		weights = append(weights, w)
+		total += w
+	}
+	if n > len(ids) {
+		n = len(ids)
+	}
+
+	h := hash.Of(seed)
+	r := rand.New(rand.NewSource(int64(bigendian.BytesToUint64(h[:8]))))
+
+	sample := make([]idx.ValidatorID, 0, n)
+	for len(sample) < n {
+		point := uint64(r.Int63n(int64(total)))
+		i := 0
+		for ; point >= weights[i]; i++ {
+			point -= weights[i]
+		}
+		sample = append(sample, ids[i])
+		// draw without replacement
+		total -= weights[i]
+		ids = append(ids[:i], ids[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+	return sample
+}
@@ -0,0 +1,43 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleValidators(t *testing.T) {
+	require := require.New(t)
+
+	b := pos.NewBuilder()
+	b.Set(1, 10)
+	b.Set(2, 20)
+	b.Set(3, 30)
+	b.Set(4, 40)
+	b.Set(5, 1000)
+	validators := b.Build()
+	cheaters := map[idx.ValidatorID]bool{5: true}
+
+	// deterministic for the same seed
+	require.Equal(sampleValidators(validators, cheaters, 2, []byte("seed")), sampleValidators(validators, cheaters, 2, []byte("seed")))
+
+	// distinct validators, cheaters are excluded
+	sample := sampleValidators(validators, cheaters, 10, []byte("seed"))
+	require.ElementsMatch([]idx.ValidatorID{1, 2, 3, 4}, sample)
+
+	// empirical distribution matches weights
+	const draws = 20000
+	counts := make(map[idx.ValidatorID]int)
+	for i := uint64(0); i < draws; i++ {
+		sample := sampleValidators(validators, cheaters, 1, bigendian.Uint64ToBytes(i))
+		require.Len(sample, 1)
+		counts[sample[0]]++
+	}
+	require.Zero(counts[5])
+	for id, weight := range map[idx.ValidatorID]float64{1: 0.1, 2: 0.2, 3: 0.3, 4: 0.4} {
+		require.InDelta(weight, float64(counts[id])/draws, 0.02, id)
+	}
+}
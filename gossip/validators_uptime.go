@@ -0,0 +1,84 @@
+package gossip
+
+import (
+	"sort"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// UptimeViolation is a validator which missed too many blocks of the epoch
+type UptimeViolation struct {
+	ValidatorID idx.ValidatorID
+	// Missed is a number of blocks since the last block confirming an event of the validator
+	Missed idx.Block
+	// Expected is a number of blocks in the epoch so far
+	Expected idx.Block
+}
+
+// CheckValidatorUptime returns validators which missed more than Config.MaxMissedBlocksRatio of the epoch blocks, ordered by ID.
+// It's a diagnostic only, validators are deactivated on-chain.
+// Blocks are tracked only for the current epoch, ErrNotCurrentEpoch is returned for other epochs.
+func (s *Service) CheckValidatorUptime(epoch idx.Epoch) ([]UptimeViolation, error) {
+	bs, es := s.store.GetBlockEpochState()
+	if es.Epoch != epoch {
+		return nil, ErrNotCurrentEpoch
+	}
+	var sealingBlock idx.Block
+	if n, ok := s.store.EvmStore().GetEpochSealBlock(epoch - 1); ok {
+		sealingBlock = idx.Block(n)
+	} else {
+		// the previous epoch was sealed before the index was introduced
+		sealingBlock = s.store.lastBlockNotAfter(es.EpochStart, bs.LastBlock.Idx)
+	}
+	return uptimeViolations(bs, es, sealingBlock, s.config.MaxMissedBlocksRatio), nil
+}
+
+// lastBlockNotAfter returns the last block, not higher than the head, which isn't after the time
+func (s *Store) lastBlockNotAfter(t inter.Timestamp, head idx.Block) idx.Block {
+	first := idx.Block(0)
+	if genesis := s.GetGenesisBlockIndex(); genesis != nil {
+		first = *genesis
+	}
+	if head < first {
+		return head
+	}
+	// blocks are ordered by time
+	after := sort.Search(int(head-first)+1, func(i int) bool {
+		block := s.GetBlock(first + idx.Block(i))
+		return block != nil && block.Time > t
+	})
+	if after == 0 {
+		return first
+	}
+	return first + idx.Block(after) - 1
+}
+
+// uptimeViolations returns validators which missed more than maxRatio of the blocks after the epoch sealing block.
+// The missed blocks are counted the same way as for the epoch sealing metrics, but capped by the epoch length.
+func uptimeViolations(bs blockproc.BlockState, es blockproc.EpochState, sealingBlock idx.Block, maxRatio float64) []UptimeViolation {
+	violations := make([]UptimeViolation, 0)
+	if bs.LastBlock.Idx <= sealingBlock {
+		return violations
+	}
+	expected := bs.LastBlock.Idx - sealingBlock
+	for _, id := range es.Validators.SortedIDs() {
+		info := bs.ValidatorStates[es.Validators.GetIdx(id)]
+		missed := expected
+		if info.LastBlock >= bs.LastBlock.Idx {
+			missed = 0
+		} else if info.LastBlock > sealingBlock {
+			missed = bs.LastBlock.Idx - info.LastBlock
+		}
+		if float64(missed) > maxRatio*float64(expected) {
+			violations = append(violations, UptimeViolation{
+				ValidatorID: id,
+				Missed:      missed,
+				Expected:    expected,
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,71 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/blockproc"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+func TestUptimeViolations(t *testing.T) {
+	require := require.New(t)
+
+	b := pos.NewBuilder()
+	for v := idx.ValidatorID(1); v <= 4; v++ {
+		b.Set(v, 10)
+	}
+	es := blockproc.EpochState{
+		Validators: b.Build(),
+	}
+	bs := blockproc.BlockState{
+		LastBlock:       blockproc.BlockCtx{Idx: 200},
+		ValidatorStates: make([]blockproc.ValidatorBlockState, 4),
+	}
+	lastBlocks := map[idx.ValidatorID]idx.Block{
+		1: 200, // online
+		2: 160, // missed 40 blocks, below the threshold
+		3: 130, // missed 70 blocks, above the threshold
+		4: 50,  // offline since the previous epoch
+	}
+	for id, n := range lastBlocks {
+		bs.GetValidatorState(id, es.Validators).LastBlock = n
+	}
+
+	// 100 blocks in the epoch
+	require.Equal([]UptimeViolation{
+		{ValidatorID: 3, Missed: 70, Expected: 100},
+		{ValidatorID: 4, Missed: 100, Expected: 100},
+	}, uptimeViolations(bs, es, 100, 0.5))
+
+	require.Equal([]UptimeViolation{
+		{ValidatorID: 4, Missed: 100, Expected: 100},
+	}, uptimeViolations(bs, es, 100, 0.9))
+
+	require.Empty(uptimeViolations(bs, es, 100, 1))
+
+	// no blocks in the epoch yet
+	require.Empty(uptimeViolations(bs, es, 200, 0.5))
+}
+
+func TestStoreLastBlockNotAfter(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	store := NewMemStore()
+	defer store.Close()
+
+	store.SetGenesisBlockIndex(2)
+	for n := idx.Block(2); n <= 10; n++ {
+		store.SetBlock(n, &inter.Block{Time: inter.Timestamp(n * 10)})
+	}
+
+	require.Equal(idx.Block(2), store.lastBlockNotAfter(0, 10))
+	require.Equal(idx.Block(5), store.lastBlockNotAfter(50, 10))
+	require.Equal(idx.Block(5), store.lastBlockNotAfter(55, 10))
+	require.Equal(idx.Block(8), store.lastBlockNotAfter(500, 8))
+}
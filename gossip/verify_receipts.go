@@ -0,0 +1,108 @@
+package gossip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+)
+
+var (
+	// ErrReceiptsMismatch is returned if stored receipts differ from the re-executed ones
+	ErrReceiptsMismatch = errors.New("receipts mismatch")
+)
+
+// VerifyReceipts re-executes block transactions on top of the previous block state
+// and compares the resulting receipts against the stored ones.
+// Note: it's a heavy diagnostic call, all the block transactions are executed.
+// The first found difference is returned as an ErrReceiptsMismatch error.
+func (r *EvmStateReader) VerifyReceipts(n idx.Block, vmConfig vm.Config) (bool, error) {
+	if n == 0 {
+		return false, errors.New("genesis block cannot be re-executed")
+	}
+	block := r.store.GetBlock(n)
+	prev := r.store.GetBlock(n - 1)
+	if block == nil || prev == nil {
+		return false, fmt.Errorf("block %d not found", n)
+	}
+	evmBlock := r.GetBlock(common.Hash{}, uint64(n))
+
+	statedb, err := r.StateAt(common.Hash(prev.Root))
+	if err != nil {
+		return false, err
+	}
+
+	// internal txs are always placed at the beginning of a block
+	internalTxs := len(block.InternalTxs)
+	if internalTxs > len(evmBlock.Transactions) {
+		return false, fmt.Errorf("block %d has inconsistent internal txs", n)
+	}
+	header := evmBlock.EvmHeader
+	header.GasUsed = 0
+	processor := evmcore.NewStateProcessor(r.Config(), r)
+	onNewLog := func(*types.Log, *state.StateDB) {}
+	var gasUsed uint64
+
+	internalReceipts, _, _, err := processor.Process(evmcore.NewEvmBlock(&header, evmBlock.Transactions[:internalTxs]), statedb, vmConfig, &gasUsed, true, onNewLog)
+	if err != nil {
+		return false, err
+	}
+	externalReceipts, _, skipped, err := processor.Process(evmcore.NewEvmBlock(&header, evmBlock.Transactions[internalTxs:]), statedb, vmConfig, &gasUsed, false, onNewLog)
+	if err != nil {
+		return false, err
+	}
+	if len(skipped) != 0 {
+		return false, fmt.Errorf("%w: %d txs are skipped by re-execution", ErrReceiptsMismatch, len(skipped))
+	}
+
+	err = compareReceipts(r.store.EvmStore().GetReceipts(n), append(internalReceipts, externalReceipts...))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// compareReceipts returns an ErrReceiptsMismatch error describing the first difference of receipts
+func compareReceipts(stored, computed types.Receipts) error {
+	if len(stored) != len(computed) {
+		return fmt.Errorf("%w: %d receipts are stored, %d are computed", ErrReceiptsMismatch, len(stored), len(computed))
+	}
+	for i, a := range stored {
+		b := computed[i]
+		if a.Status != b.Status {
+			return fmt.Errorf("%w: tx %d status %d != %d", ErrReceiptsMismatch, i, a.Status, b.Status)
+		}
+		if a.CumulativeGasUsed != b.CumulativeGasUsed {
+			return fmt.Errorf("%w: tx %d cumulative gas used %d != %d", ErrReceiptsMismatch, i, a.CumulativeGasUsed, b.CumulativeGasUsed)
+		}
+		if len(a.Logs) != len(b.Logs) {
+			return fmt.Errorf("%w: tx %d has %d logs != %d", ErrReceiptsMismatch, i, len(a.Logs), len(b.Logs))
+		}
+		for j, la := range a.Logs {
+			lb := b.Logs[j]
+			if la.Address != lb.Address || !equalTopics(la.Topics, lb.Topics) || !bytes.Equal(la.Data, lb.Data) {
+				return fmt.Errorf("%w: tx %d log %d differs", ErrReceiptsMismatch, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func equalTopics(a, b []common.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
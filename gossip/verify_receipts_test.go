@@ -0,0 +1,77 @@
+package gossip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/opera"
+)
+
+func TestCompareReceipts(t *testing.T) {
+	require := require.New(t)
+
+	makeReceipts := func() types.Receipts {
+		return types.Receipts{
+			{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000},
+			{
+				Status:            types.ReceiptStatusSuccessful,
+				CumulativeGasUsed: 50000,
+				Logs: []*types.Log{{
+					Address: common.Address{1},
+					Topics:  []common.Hash{{2}},
+					Data:    []byte{3},
+				}},
+			},
+		}
+	}
+
+	require.NoError(compareReceipts(makeReceipts(), makeReceipts()))
+
+	tampered := makeReceipts()
+	tampered[0].Status = types.ReceiptStatusFailed
+	require.True(errors.Is(compareReceipts(tampered, makeReceipts()), ErrReceiptsMismatch))
+
+	tampered = makeReceipts()
+	tampered[1].CumulativeGasUsed++
+	require.True(errors.Is(compareReceipts(tampered, makeReceipts()), ErrReceiptsMismatch))
+
+	tampered = makeReceipts()
+	tampered[1].Logs[0].Data = []byte{4}
+	require.True(errors.Is(compareReceipts(tampered, makeReceipts()), ErrReceiptsMismatch))
+
+	require.True(errors.Is(compareReceipts(makeReceipts()[:1], makeReceipts()), ErrReceiptsMismatch))
+}
+
+func TestVerifyReceipts(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv()
+	defer env.Close()
+
+	// epoch sealing block always has internal txs
+	env.ApplyBlock(nextEpoch)
+	n := env.store.GetLatestBlockIndex()
+	stored := env.store.EvmStore().GetReceipts(n)
+	require.NotEmpty(stored)
+
+	ok, err := env.stateReader.VerifyReceipts(n, opera.DefaultVMConfig)
+	require.NoError(err)
+	require.True(ok)
+
+	tampered := make(types.Receipts, len(stored))
+	for i, r := range stored {
+		cp := *r
+		tampered[i] = &cp
+	}
+	tampered[0].CumulativeGasUsed++
+	env.store.EvmStore().SetReceipts(n, tampered)
+	ok, err = env.stateReader.VerifyReceipts(n, opera.DefaultVMConfig)
+	require.True(errors.Is(err, ErrReceiptsMismatch), err)
+	require.False(ok)
+}
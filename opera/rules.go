@@ -246,7 +246,14 @@ func FakeShortGasPowerRules() GasPowerRules {
 
 func (r Rules) Copy() Rules {
 	cp := r
-	cp.Economy.MinGasPrice = new(big.Int).Set(r.Economy.MinGasPrice)
+	cp.Economy = r.Economy.Copy()
+	return cp
+}
+
+// Copy returns a deep copy of the economy rules
+func (r EconomyRules) Copy() EconomyRules {
+	cp := r
+	cp.MinGasPrice = new(big.Int).Set(r.MinGasPrice)
 	return cp
 }
 
@@ -0,0 +1,55 @@
+package topicsdb
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Batch buffers log records pushed into the index, which are written in one DB write by Commit.
+// Queries don't see the buffered records until they're committed.
+// Batch isn't safe for concurrent use.
+type Batch struct {
+	batch  kvdb.Batch
+	topic  kvdb.Writer
+	logrec kvdb.Writer
+}
+
+// BeginBatch starts a new batch of log records.
+func (tt *Index) BeginBatch() *Batch {
+	batch := tt.db.NewBatch()
+	// prefixes are the same as the tables ones
+	return &Batch{
+		batch:  batch,
+		topic:  &prefixedWriter{batch, []byte("t")},
+		logrec: &prefixedWriter{batch, []byte("r")},
+	}
+}
+
+// Push buffers log records.
+func (b *Batch) Push(recs ...*types.Log) error {
+	return push(b.topic, b.logrec, recs)
+}
+
+// ValueSize returns the size of the buffered data.
+func (b *Batch) ValueSize() int {
+	return b.batch.ValueSize()
+}
+
+// Commit writes the buffered log records, the batch may be reused after that.
+func (b *Batch) Commit() error {
+	defer b.batch.Reset()
+	return b.batch.Write()
+}
+
+type prefixedWriter struct {
+	w      kvdb.Writer
+	prefix []byte
+}
+
+func (w *prefixedWriter) Put(key []byte, value []byte) error {
+	return w.w.Put(append(append(make([]byte, 0, len(w.prefix)+len(key)), w.prefix...), key...), value)
+}
+
+func (w *prefixedWriter) Delete(key []byte) error {
+	return w.w.Delete(append(append(make([]byte, 0, len(w.prefix)+len(key)), w.prefix...), key...))
+}
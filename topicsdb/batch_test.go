@@ -0,0 +1,114 @@
+package topicsdb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/leveldb"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+)
+
+// logsByBlocks splits the log records, which are ordered by blocks, into blocks
+func logsByBlocks(recs []*types.Log) [][]*types.Log {
+	var blocks [][]*types.Log
+	for i, rec := range recs {
+		if i == 0 || rec.BlockNumber != recs[i-1].BlockNumber {
+			blocks = append(blocks, nil)
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], rec)
+	}
+	return blocks
+}
+
+func dbContents(db kvdb.Store) map[string]string {
+	res := make(map[string]string)
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		res[string(it.Key())] = string(it.Value())
+	}
+	return res
+}
+
+func TestIndexBatch(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	_, recs, _ := genTestData(1000)
+	blocks := logsByBlocks(recs)
+
+	perBlockDB := memorydb.New()
+	perBlock := New(perBlockDB)
+	for _, logs := range blocks {
+		require.NoError(perBlock.Push(logs...))
+	}
+
+	batchedDB := memorydb.New()
+	batched := New(batchedDB)
+	batch := batched.BeginBatch()
+	pattern := [][]common.Hash{{recs[0].Address.Hash()}}
+	for i, logs := range blocks {
+		require.NoError(batch.Push(logs...))
+		if i == 0 {
+			// queries don't see the partial batch
+			require.NotZero(batch.ValueSize())
+			got, err := batched.FindInBlocks(context.Background(), 0, 1000, pattern)
+			require.NoError(err)
+			require.Empty(got)
+		}
+		if i%30 == 29 {
+			require.NoError(batch.Commit())
+		}
+	}
+	require.NoError(batch.Commit())
+
+	require.Equal(dbContents(perBlockDB), dbContents(batchedDB))
+	got, err := batched.FindInBlocks(context.Background(), 0, 1000, pattern)
+	require.NoError(err)
+	require.Len(got, 1)
+	require.Equal(recs[0].Data, got[0].Data)
+}
+
+func BenchmarkIndexReindex(b *testing.B) {
+	_, recs, _ := genTestData(1000)
+	blocks := logsByBlocks(recs)
+
+	for _, batchBlocks := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("batch_%d_blocks", batchBlocks), func(b *testing.B) {
+			dir, err := ioutil.TempDir("", "topicsdb_reindex_bench")
+			require.NoError(b, err)
+			defer os.RemoveAll(dir)
+			db, err := leveldb.New(dir, 16, 0, nil, nil)
+			require.NoError(b, err)
+			defer db.Close()
+			index := New(db)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if batchBlocks == 1 {
+					for _, logs := range blocks {
+						require.NoError(b, index.Push(logs...))
+					}
+					continue
+				}
+				batch := index.BeginBatch()
+				for j, logs := range blocks {
+					require.NoError(b, batch.Push(logs...))
+					if (j+1)%batchBlocks == 0 {
+						require.NoError(b, batch.Commit())
+					}
+				}
+				require.NoError(b, batch.Commit())
+			}
+		})
+	}
+}
@@ -100,6 +100,44 @@ func (tt *Index) ForEachInBlocks(ctx context.Context, from, to idx.Block, patter
 	return tt.searchLazy(ctx, pattern, uintToBytes(uint64(from)), uint64(to), onMatched)
 }
 
+// CountInBlocks counts log records of block range matched by pattern, without fetching them. 1st pattern element is an address.
+// Duplicate variants of the pattern are counted once. Non-zero limit stops the counting once it's exceeded.
+func (tt *Index) CountInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash, limit uint64) (count uint64, err error) {
+	if from > to {
+		return 0, nil
+	}
+
+	pattern, err = limitPattern(pattern)
+	if err != nil {
+		return 0, err
+	}
+	// a log has a single topic at each position, so it's matched once if variants are unique
+	for i, variants := range pattern {
+		pattern[i] = uniqueHashes(variants)
+	}
+
+	onMatched := func(rec *logrec) (gonext bool, err error) {
+		count++
+		return limit == 0 || count <= limit, nil
+	}
+
+	err = tt.searchLazy(ctx, pattern, uintToBytes(uint64(from)), uint64(to), onMatched)
+	return count, err
+}
+
+func uniqueHashes(hh []common.Hash) []common.Hash {
+	seen := make(map[common.Hash]bool, len(hh))
+	unique := make([]common.Hash, 0, len(hh))
+	for _, h := range hh {
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		unique = append(unique, h)
+	}
+	return unique
+}
+
 func limitPattern(pattern [][]common.Hash) (limited [][]common.Hash, err error) {
 	if len(pattern) > MaxTopicsCount {
 		limited = make([][]common.Hash, MaxTopicsCount)
@@ -130,6 +168,10 @@ func (tt *Index) MustPush(recs ...*types.Log) {
 
 // Write log record to database.
 func (tt *Index) Push(recs ...*types.Log) error {
+	return push(tt.table.Topic, tt.table.Logrec, recs)
+}
+
+func push(topicTable, logrecTable kvdb.Writer, recs []*types.Log) error {
 	for _, rec := range recs {
 		var (
 			id    = NewID(rec.BlockNumber, rec.TxHash, rec.Index)
@@ -138,7 +180,7 @@ func (tt *Index) Push(recs ...*types.Log) error {
 		)
 		pushIndex := func(topic common.Hash) error {
 			key := topicKey(topic, pos, id)
-			if err := tt.table.Topic.Put(key, count); err != nil {
+			if err := topicTable.Put(key, count); err != nil {
 				return err
 			}
 			pos++
@@ -164,7 +206,7 @@ func (tt *Index) Push(recs ...*types.Log) error {
 		buf = append(buf, rec.Address.Bytes()...)
 		buf = append(buf, rec.Data...)
 
-		if err := tt.table.Logrec.Put(id.Bytes(), buf); err != nil {
+		if err := logrecTable.Put(id.Bytes(), buf); err != nil {
 			return err
 		}
 	}